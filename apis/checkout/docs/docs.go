@@ -46,46 +46,107 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/response.Exception"
                         }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Exception"
+                        }
                     }
                 }
             }
         },
-        "/api/checkout/transactions/country/{country}": {
+        "/api/checkout/convert": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Checkout Orders"
+                    "Checkout Rates"
                 ],
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "country",
+                        "description": "amount to convert, e.g. 1250.00",
+                        "name": "amount",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "target currency: country name (English or Portuguese), ISO 4217 currency code, ISO 3166 country code or Treasury country_currency_desc",
                         "name": "country",
-                        "in": "path",
+                        "in": "query",
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "limit min 1",
-                        "name": "limit",
+                        "type": "string",
+                        "description": "date of the rates, YYYY-MM-DD, today when empty",
+                        "name": "date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "currency of the amount, USD when empty",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "rate selection: on-or-before, strictly-before, nearest or exact",
+                        "name": "rate_policy",
                         "in": "query"
                     },
                     {
                         "type": "integer",
-                        "default": 0,
-                        "description": "offset min 0",
-                        "name": "offset",
+                        "description": "maximum distance in days between each rate and the date, 0 for no limit",
+                        "name": "rate_max_age_days",
                         "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Converted"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Exception"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Checkout Rates"
+                ],
+                "parameters": [
+                    {
+                        "description": "Body JSON, amounts in from (default USD) converted to currency on date (default today)",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.ConvertBatch"
+                        }
                     },
                     {
                         "type": "string",
-                        "description": "filter_transaction_date",
-                        "name": "filter_transaction_date",
-                        "in": "query",
-                        "required": true
+                        "description": "rate selection: on-or-before, strictly-before, nearest or exact",
+                        "name": "rate_policy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "maximum distance in days between each rate and the date, 0 for no limit",
+                        "name": "rate_max_age_days",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -102,7 +163,7 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/response.GetTransactions"
+                                                "$ref": "#/definitions/response.Converted"
                                             }
                                         }
                                     }
@@ -119,35 +180,53 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/checkout/transactions/{transactionID}/country/{country}": {
+        "/api/checkout/cross-rates": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Checkout Orders"
+                    "Checkout Rates"
                 ],
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "transactionID",
-                        "name": "transactionID",
-                        "in": "path",
+                        "type": "string",
+                        "description": "source currency: country name (English or Portuguese), ISO 4217 currency code, ISO 3166 country code or Treasury country_currency_desc",
+                        "name": "from",
+                        "in": "query",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "country",
-                        "name": "country",
-                        "in": "path",
+                        "description": "target currency, same formats as from",
+                        "name": "to",
+                        "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "date of the rates, YYYY-MM-DD, today when empty",
+                        "name": "date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "rate selection: on-or-before, strictly-before, nearest or exact",
+                        "name": "rate_policy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "maximum distance in days between each rate and the date, 0 for no limit",
+                        "name": "rate_max_age_days",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.GetTransactionsByID"
+                            "$ref": "#/definitions/response.CrossRate"
                         }
                     },
                     "400": {
@@ -158,85 +237,1414 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "request.InsertTransaction": {
-            "type": "object",
-            "properties": {
-                "description": {
-                    "type": "string"
-                },
-                "transaction_date": {
-                    "type": "string"
-                },
-                "transaction_value": {
-                    "type": "number"
-                }
-            }
         },
-        "response.Created": {
-            "type": "object",
-            "properties": {
-                "id": {
-                    "type": "integer"
+        "/api/checkout/currencies": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Checkout Rates"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "prefix of the country, currency or country_currency_desc",
+                        "name": "filter_name",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.List"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/response.GetCurrencies"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Exception"
+                        }
+                    }
                 }
             }
         },
-        "response.Exception": {
-            "type": "object",
-            "properties": {
-                "key": {
-                    "type": "string"
-                },
-                "message": {
-                    "type": "string"
+        "/api/checkout/customers": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Checkout Customers"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "limit min 1",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "offset min 0",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.List"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/response.Customer"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Exception"
+                        }
+                    }
                 }
-            }
-        },
-        "response.GetTransactions": {
-            "type": "object",
-            "properties": {
-                "description": {
-                    "type": "string"
-                },
-                "exchange_rate": {
-                    "type": "number"
-                },
-                "id": {
-                    "type": "integer"
-                },
-                "transaction_date": {
-                    "type": "string"
-                },
-                "transaction_value": {
-                    "type": "number"
-                },
-                "transaction_value_converted_to_wish_currency": {
-                    "type": "number"
+            },
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Checkout Customers"
+                ],
+                "parameters": [
+                    {
+                        "description": "Body JSON",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.InsertCustomer"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/response.Created"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Exception"
+                        }
+                    }
                 }
             }
         },
-        "response.GetTransactionsByID": {
+        "/api/checkout/customers/{customerID}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Checkout Customers"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "customerID",
+                        "name": "customerID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Customer"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Exception"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Exception"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Checkout Customers"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "customerID",
+                        "name": "customerID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Updated"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Exception"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Exception"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Checkout Customers"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "customerID",
+                        "name": "customerID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Body JSON, only the fields to change",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UpdateCustomer"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Updated"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Exception"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Exception"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/checkout/customers/{customerID}/transactions": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Checkout Customers"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "customerID",
+                        "name": "customerID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "country name (English or Portuguese), ISO 4217 currency code, ISO 3166 country code or Treasury country_currency_desc",
+                        "name": "country",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "limit min 1",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "offset min 0",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "filter_transaction_date",
+                        "name": "filter_transaction_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "pending, paid, cancelled or refunded",
+                        "name": "filter_status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated extra currencies, e.g. Brazil,Canada,JPY",
+                        "name": "currencies",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "rate selection: on-or-before, strictly-before, nearest or exact",
+                        "name": "rate_policy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "maximum distance in days between the rate and the transaction date, 0 for no limit",
+                        "name": "rate_max_age_days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "recompute the conversions instead of returning the stored ones",
+                        "name": "refresh",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "include deleted orders",
+                        "name": "filter_include_deleted",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.CustomerTransactions"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Exception"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Exception"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/checkout/quotes": {
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Checkout Orders"
+                ],
+                "parameters": [
+                    {
+                        "description": "Body JSON, amount in USD",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.InsertQuote"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/response.Quote"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Exception"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/checkout/rates/{country}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Checkout Rates"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "country name (English or Portuguese), ISO 4217 currency code, ISO 3166 country code or Treasury country_currency_desc",
+                        "name": "country",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "first effective date, YYYY-MM-DD",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "last effective date, YYYY-MM-DD",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "limit min 1",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "offset min 0",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.List"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/response.GetRateHistory"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Exception"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/checkout/transactions/country/{country}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Checkout Orders"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "country name (English or Portuguese), ISO 4217 currency code, ISO 3166 country code or Treasury country_currency_desc",
+                        "name": "country",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "limit min 1",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "offset min 0",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "filter_transaction_date",
+                        "name": "filter_transaction_date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated extra currencies, e.g. Brazil,Canada,JPY",
+                        "name": "currencies",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "rate selection: on-or-before, strictly-before, nearest or exact",
+                        "name": "rate_policy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "maximum distance in days between the rate and the transaction date, 0 for no limit",
+                        "name": "rate_max_age_days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "recompute the conversions instead of returning the stored ones",
+                        "name": "refresh",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "include deleted orders",
+                        "name": "filter_include_deleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "pending, paid, cancelled or refunded",
+                        "name": "filter_status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.List"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/response.GetTransactions"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Exception"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/checkout/transactions/{transactionID}": {
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Checkout Orders"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "transactionID",
+                        "name": "transactionID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Updated"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Exception"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Exception"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Checkout Orders"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "transactionID",
+                        "name": "transactionID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Body JSON, only the fields to change",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UpdateTransaction"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Updated"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Exception"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Exception"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/checkout/transactions/{transactionID}/country/{country}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Checkout Orders"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "transactionID",
+                        "name": "transactionID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "country name (English or Portuguese), ISO 4217 currency code, ISO 3166 country code or Treasury country_currency_desc",
+                        "name": "country",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated extra currencies, e.g. Brazil,Canada,JPY",
+                        "name": "currencies",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "rate selection: on-or-before, strictly-before, nearest or exact",
+                        "name": "rate_policy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "maximum distance in days between the rate and the transaction date, 0 for no limit",
+                        "name": "rate_max_age_days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "recompute the conversions instead of returning the stored ones",
+                        "name": "refresh",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "include deleted orders",
+                        "name": "filter_include_deleted",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.GetTransactionsByID"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Exception"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Exception"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/checkout/transactions/{transactionID}/refunds": {
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Checkout Orders"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "transactionID",
+                        "name": "transactionID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Body JSON, amount in the order currency and refund_date defaulting to now",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.InsertRefund"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/response.Created"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Exception"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Exception"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/checkout/transactions/{transactionID}/restore": {
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Checkout Orders"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "transactionID",
+                        "name": "transactionID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Updated"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Exception"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Exception"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/checkout/transactions/{transactionID}/transitions": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Checkout Orders"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "transactionID",
+                        "name": "transactionID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "include deleted orders",
+                        "name": "filter_include_deleted",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.List"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/response.StatusTransition"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Exception"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Exception"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Checkout Orders"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "transactionID",
+                        "name": "transactionID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Body JSON, status pending, paid, cancelled or refunded",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.TransitionStatus"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/response.StatusTransition"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Exception"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Exception"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "request.ConvertBatch": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/request.ConvertItem"
+                    }
+                }
+            }
+        },
+        "request.ConvertItem": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "from": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.InsertCustomer": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.InsertItem": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "sku": {
+                    "type": "string"
+                },
+                "unit_price": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.InsertQuote": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.InsertRefund": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "refund_date": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.InsertTransaction": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string"
+                },
+                "customer_id": {
+                    "type": "integer"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/request.InsertItem"
+                    }
+                },
+                "quote_id": {
+                    "type": "integer"
+                },
+                "transaction_date": {
+                    "type": "string"
+                },
+                "transaction_value": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.TransitionStatus": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.UpdateCustomer": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.UpdateTransaction": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "transaction_date": {
+                    "type": "string"
+                },
+                "transaction_value": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.Conversion": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string"
+                },
+                "error": {
+                    "$ref": "#/definitions/response.Exception"
+                },
+                "exchange_rate": {
+                    "type": "string"
+                },
+                "legs": {
+                    "$ref": "#/definitions/response.RateLegs"
+                },
+                "stale": {
+                    "type": "boolean"
+                },
+                "transaction_value_converted_to_wish_currency": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.Converted": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "string"
+                },
+                "converted_amount": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "error": {
+                    "$ref": "#/definitions/response.Exception"
+                },
+                "exchange_rate": {
+                    "type": "string"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "legs": {
+                    "$ref": "#/definitions/response.RateLegs"
+                },
+                "stale": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "response.Created": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.CrossRate": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string"
+                },
+                "exchange_rate": {
+                    "type": "string"
+                },
+                "legs": {
+                    "$ref": "#/definitions/response.RateLegs"
+                },
+                "stale": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "response.Customer": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.CustomerTotals": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string"
+                },
+                "transaction_count": {
+                    "type": "integer"
+                },
+                "transaction_value_converted_to_wish_currency": {
+                    "type": "string"
+                },
+                "transaction_value_usd": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.CustomerTransactions": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.GetTransactions"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/response.Pagination"
+                },
+                "totals": {
+                    "$ref": "#/definitions/response.CustomerTotals"
+                }
+            }
+        },
+        "response.Exception": {
+            "type": "object",
+            "properties": {
+                "key": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.GetCurrencies": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "country": {
+                    "type": "string"
+                },
+                "country_currency_desc": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "effective_date": {
+                    "type": "string"
+                },
+                "exchange_rate": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.GetRateHistory": {
+            "type": "object",
+            "properties": {
+                "country_currency_desc": {
+                    "type": "string"
+                },
+                "effective_date": {
+                    "type": "string"
+                },
+                "exchange_rate": {
+                    "type": "string"
+                },
+                "record_date": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.GetTransactions": {
+            "type": "object",
+            "properties": {
+                "conversions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.Conversion"
+                    }
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "customer_id": {
+                    "type": "integer"
+                },
+                "deleted": {
+                    "type": "boolean"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "exchange_rate": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "legs": {
+                    "$ref": "#/definitions/response.RateLegs"
+                },
+                "stale": {
+                    "type": "boolean"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "transaction_date": {
+                    "type": "string"
+                },
+                "transaction_value": {
+                    "type": "string"
+                },
+                "transaction_value_converted_to_wish_currency": {
+                    "type": "string"
+                },
+                "transaction_value_usd": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.GetTransactionsByID": {
             "type": "object",
             "properties": {
+                "conversions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.Conversion"
+                    }
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "customer_id": {
+                    "type": "integer"
+                },
+                "deleted": {
+                    "type": "boolean"
+                },
                 "description": {
                     "type": "string"
                 },
                 "exchange_rate": {
-                    "type": "number"
+                    "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.Item"
+                    }
+                },
+                "legs": {
+                    "$ref": "#/definitions/response.RateLegs"
+                },
+                "net_value": {
+                    "type": "string"
+                },
+                "net_value_converted_to_wish_currency": {
+                    "type": "string"
+                },
+                "quote_id": {
+                    "type": "integer"
+                },
+                "refunded_value": {
+                    "type": "string"
+                },
+                "refunds": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.Refund"
+                    }
+                },
+                "stale": {
+                    "type": "boolean"
+                },
+                "status": {
+                    "type": "string"
+                },
                 "transaction_date": {
                     "type": "string"
                 },
                 "transaction_value": {
-                    "type": "number"
+                    "type": "string"
                 },
                 "transaction_value_converted_to_wish_currency": {
-                    "type": "number"
+                    "type": "string"
+                },
+                "transaction_value_usd": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.Item": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "sku": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "string"
+                },
+                "total_converted_to_wish_currency": {
+                    "type": "string"
+                },
+                "unit_price": {
+                    "type": "string"
+                },
+                "unit_price_converted_to_wish_currency": {
+                    "type": "string"
                 }
             }
         },
@@ -256,6 +1664,123 @@ const docTemplate = `{
                     "type": "integer"
                 }
             }
+        },
+        "response.Quote": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "string"
+                },
+                "converted_amount": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "effective_date": {
+                    "type": "string"
+                },
+                "exchange_rate": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.RateLeg": {
+            "type": "object",
+            "properties": {
+                "age_days": {
+                    "type": "integer"
+                },
+                "country_currency_desc": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "effective_date": {
+                    "type": "string"
+                },
+                "stale": {
+                    "type": "boolean"
+                },
+                "usd_exchange_rate": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.RateLegs": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "$ref": "#/definitions/response.RateLeg"
+                },
+                "to": {
+                    "$ref": "#/definitions/response.RateLeg"
+                }
+            }
+        },
+        "response.Refund": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "string"
+                },
+                "amount_converted_to_wish_currency": {
+                    "type": "string"
+                },
+                "exchange_rate": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "legs": {
+                    "$ref": "#/definitions/response.RateLegs"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "refund_date": {
+                    "type": "string"
+                },
+                "stale": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "response.StatusTransition": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "from_status": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "to_status": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.Updated": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                }
+            }
         }
     }
 }`
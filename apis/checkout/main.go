@@ -5,6 +5,7 @@ import (
 	"github.com/luancpereira/APICheckout/apis/checkout/server"
 	"github.com/luancpereira/APICheckout/core/database"
 	"github.com/luancpereira/APICheckout/core/errors"
+	"github.com/luancpereira/APICheckout/core/service"
 )
 
 func init() {
@@ -20,5 +21,10 @@ func init() {
 
 // main entrypoint application
 func main() {
+	err := service.ExchangeRate{}.StartSync()
+	if err != nil {
+		panic(err)
+	}
+
 	server.NewServer().Start()
 }
@@ -1,17 +1,73 @@
 package request
 
-import "time"
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
 
 /*****
 struct for posts
 ******/
 
 type InsertTransaction struct {
-	Description      string    `json:"description"`
-	TransactionDate  time.Time `json:"transaction_date"`
-	TransactionValue float64   `json:"transaction_value"`
+	Description      string          `json:"description"`
+	TransactionDate  time.Time       `json:"transaction_date"`
+	TransactionValue decimal.Decimal `json:"transaction_value" swaggertype:"string"`
+	Currency         string          `json:"currency"`
+	QuoteID          int64           `json:"quote_id"`
+	Items            []InsertItem    `json:"items"`
+	CustomerID       int64           `json:"customer_id"`
+}
+
+type InsertItem struct {
+	Sku       string          `json:"sku"`
+	Name      string          `json:"name"`
+	Quantity  int32           `json:"quantity"`
+	UnitPrice decimal.Decimal `json:"unit_price" swaggertype:"string"`
+}
+
+type ConvertBatch struct {
+	Items []ConvertItem `json:"items"`
+}
+
+type ConvertItem struct {
+	Amount   decimal.Decimal `json:"amount" swaggertype:"string"`
+	Date     string          `json:"date"`
+	From     string          `json:"from"`
+	Currency string          `json:"currency"`
+}
+
+type InsertQuote struct {
+	Amount   decimal.Decimal `json:"amount" swaggertype:"string"`
+	Currency string          `json:"currency"`
+}
+
+type InsertRefund struct {
+	Amount     decimal.Decimal `json:"amount" swaggertype:"string"`
+	Reason     string          `json:"reason"`
+	RefundDate time.Time       `json:"refund_date"`
+}
+
+type TransitionStatus struct {
+	Status string `json:"status"`
+	Reason string `json:"reason"`
 }
 
 /*****
 struct for posts
 ******/
+
+/*****
+struct for patches
+******/
+
+type UpdateTransaction struct {
+	Description      *string          `json:"description"`
+	TransactionDate  *time.Time       `json:"transaction_date"`
+	TransactionValue *decimal.Decimal `json:"transaction_value" swaggertype:"string"`
+}
+
+/*****
+struct for patches
+******/
@@ -0,0 +1,27 @@
+package request
+
+/*****
+struct for posts
+******/
+
+type InsertCustomer struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+/*****
+struct for posts
+******/
+
+/*****
+struct for patches
+******/
+
+type UpdateCustomer struct {
+	Name  *string `json:"name"`
+	Email *string `json:"email"`
+}
+
+/*****
+struct for patches
+******/
@@ -2,6 +2,8 @@ package response
 
 import (
 	"time"
+
+	"github.com/shopspring/decimal"
 )
 
 /*****
@@ -12,6 +14,24 @@ type ReturnInsertTransaction struct {
 	ID int64 `json:"id"`
 }
 
+type Quote struct {
+	ID              int64           `json:"id"`
+	Currency        string          `json:"currency"`
+	Amount          decimal.Decimal `json:"amount" swaggertype:"string"`
+	ExchangeRate    decimal.Decimal `json:"exchange_rate" swaggertype:"string"`
+	ConvertedAmount decimal.Decimal `json:"converted_amount" swaggertype:"string"`
+	EffectiveDate   string          `json:"effective_date,omitempty"`
+	ExpiresAt       time.Time       `json:"expires_at"`
+}
+
+type StatusTransition struct {
+	ID         int64     `json:"id"`
+	FromStatus string    `json:"from_status"`
+	ToStatus   string    `json:"to_status"`
+	Reason     string    `json:"reason"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
 /*****
 struct for posts
 ******/
@@ -21,21 +41,107 @@ struct for gets
 ******/
 
 type GetTransactions struct {
-	ID                                      int64     `json:"id"`
-	Description                             string    `json:"description"`
-	TransactionDate                         time.Time `json:"transaction_date"`
-	TransactionValue                        float64   `json:"transaction_value"`
-	ExchangeRate                            float64   `json:"exchange_rate"`
-	TransactionValueConvertedToWishCurrency float64   `json:"transaction_value_converted_to_wish_currency"`
+	ID                                      int64           `json:"id"`
+	Description                             string          `json:"description"`
+	TransactionDate                         time.Time       `json:"transaction_date"`
+	TransactionValue                        decimal.Decimal `json:"transaction_value" swaggertype:"string"`
+	Currency                                string          `json:"currency"`
+	TransactionValueUsd                     decimal.Decimal `json:"transaction_value_usd" swaggertype:"string"`
+	ExchangeRate                            decimal.Decimal `json:"exchange_rate" swaggertype:"string"`
+	TransactionValueConvertedToWishCurrency decimal.Decimal `json:"transaction_value_converted_to_wish_currency" swaggertype:"string"`
+	Legs                                    RateLegs        `json:"legs"`
+	Stale                                   bool            `json:"stale"`
+	Status                                  string          `json:"status"`
+	CustomerID                              int64           `json:"customer_id,omitempty"`
+	Deleted                                 bool            `json:"deleted,omitempty"`
+	Conversions                             []Conversion    `json:"conversions,omitempty"`
 }
 
 type GetTransactionsByID struct {
-	ID                                      int64     `json:"id"`
-	Description                             string    `json:"description"`
-	TransactionDate                         time.Time `json:"transaction_date"`
-	TransactionValue                        float64   `json:"transaction_value"`
-	ExchangeRate                            float64   `json:"exchange_rate"`
-	TransactionValueConvertedToWishCurrency float64   `json:"transaction_value_converted_to_wish_currency"`
+	ID                                      int64           `json:"id"`
+	Description                             string          `json:"description"`
+	TransactionDate                         time.Time       `json:"transaction_date"`
+	TransactionValue                        decimal.Decimal `json:"transaction_value" swaggertype:"string"`
+	Currency                                string          `json:"currency"`
+	TransactionValueUsd                     decimal.Decimal `json:"transaction_value_usd" swaggertype:"string"`
+	ExchangeRate                            decimal.Decimal `json:"exchange_rate" swaggertype:"string"`
+	TransactionValueConvertedToWishCurrency decimal.Decimal `json:"transaction_value_converted_to_wish_currency" swaggertype:"string"`
+	Legs                                    RateLegs        `json:"legs"`
+	Stale                                   bool            `json:"stale"`
+	QuoteID                                 int64           `json:"quote_id,omitempty"`
+	Status                                  string          `json:"status"`
+	CustomerID                              int64           `json:"customer_id,omitempty"`
+	Deleted                                 bool            `json:"deleted,omitempty"`
+	Conversions                             []Conversion    `json:"conversions,omitempty"`
+	Items                                   []Item          `json:"items,omitempty"`
+	Refunds                                 []Refund        `json:"refunds,omitempty"`
+	RefundedValue                           decimal.Decimal `json:"refunded_value" swaggertype:"string"`
+	NetValue                                decimal.Decimal `json:"net_value" swaggertype:"string"`
+	NetValueConvertedToWishCurrency         decimal.Decimal `json:"net_value_converted_to_wish_currency" swaggertype:"string"`
+}
+
+type Item struct {
+	ID                               int64           `json:"id"`
+	Sku                              string          `json:"sku"`
+	Name                             string          `json:"name"`
+	Quantity                         int32           `json:"quantity"`
+	UnitPrice                        decimal.Decimal `json:"unit_price" swaggertype:"string"`
+	Total                            decimal.Decimal `json:"total" swaggertype:"string"`
+	UnitPriceConvertedToWishCurrency decimal.Decimal `json:"unit_price_converted_to_wish_currency" swaggertype:"string"`
+	TotalConvertedToWishCurrency     decimal.Decimal `json:"total_converted_to_wish_currency" swaggertype:"string"`
+}
+
+type Refund struct {
+	ID                            int64           `json:"id"`
+	Amount                        decimal.Decimal `json:"amount" swaggertype:"string"`
+	Reason                        string          `json:"reason"`
+	RefundDate                    time.Time       `json:"refund_date"`
+	ExchangeRate                  decimal.Decimal `json:"exchange_rate" swaggertype:"string"`
+	AmountConvertedToWishCurrency decimal.Decimal `json:"amount_converted_to_wish_currency" swaggertype:"string"`
+	Legs                          RateLegs        `json:"legs"`
+	Stale                         bool            `json:"stale"`
+}
+
+type Conversion struct {
+	Currency                                string          `json:"currency"`
+	ExchangeRate                            decimal.Decimal `json:"exchange_rate" swaggertype:"string"`
+	TransactionValueConvertedToWishCurrency decimal.Decimal `json:"transaction_value_converted_to_wish_currency" swaggertype:"string"`
+	Legs                                    *RateLegs       `json:"legs,omitempty"`
+	Stale                                   bool            `json:"stale"`
+	Error                                   *Exception      `json:"error,omitempty"`
+}
+
+type CrossRate struct {
+	Date         string          `json:"date"`
+	ExchangeRate decimal.Decimal `json:"exchange_rate" swaggertype:"string"`
+	Legs         RateLegs        `json:"legs"`
+	Stale        bool            `json:"stale"`
+}
+
+type RateLegs struct {
+	From RateLeg `json:"from"`
+	To   RateLeg `json:"to"`
+}
+
+type RateLeg struct {
+	Currency            string          `json:"currency"`
+	CountryCurrencyDesc string          `json:"country_currency_desc"`
+	ExchangeRate        decimal.Decimal `json:"usd_exchange_rate" swaggertype:"string"`
+	EffectiveDate       string          `json:"effective_date,omitempty"`
+	AgeDays             int             `json:"age_days"`
+	Stale               bool            `json:"stale"`
+}
+
+type Converted struct {
+	Date            string          `json:"date"`
+	Amount          decimal.Decimal `json:"amount" swaggertype:"string"`
+	From            string          `json:"from"`
+	Currency        string          `json:"currency"`
+	ExchangeRate    decimal.Decimal `json:"exchange_rate" swaggertype:"string"`
+	ConvertedAmount decimal.Decimal `json:"converted_amount" swaggertype:"string"`
+	Legs            *RateLegs       `json:"legs,omitempty"`
+	Stale           bool            `json:"stale"`
+	Error           *Exception      `json:"error,omitempty"`
 }
 
 /*****
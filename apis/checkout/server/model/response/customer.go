@@ -0,0 +1,35 @@
+package response
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+/*****
+struct for gets
+******/
+
+type Customer struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	Email     string    `json:"email"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type CustomerTransactions struct {
+	Pagination Pagination        `json:"pagination"`
+	Totals     CustomerTotals    `json:"totals"`
+	Data       []GetTransactions `json:"data"`
+}
+
+type CustomerTotals struct {
+	Currency                                string          `json:"currency"`
+	TransactionCount                        int64           `json:"transaction_count"`
+	TransactionValueUsd                     decimal.Decimal `json:"transaction_value_usd" swaggertype:"string"`
+	TransactionValueConvertedToWishCurrency decimal.Decimal `json:"transaction_value_converted_to_wish_currency" swaggertype:"string"`
+}
+
+/*****
+struct for gets
+******/
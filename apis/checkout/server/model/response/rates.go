@@ -0,0 +1,27 @@
+package response
+
+import "github.com/shopspring/decimal"
+
+/*****
+struct for gets
+******/
+
+type GetCurrencies struct {
+	Country             string          `json:"country"`
+	Currency            string          `json:"currency"`
+	CountryCurrencyDesc string          `json:"country_currency_desc"`
+	Code                string          `json:"code,omitempty"`
+	EffectiveDate       string          `json:"effective_date"`
+	ExchangeRate        decimal.Decimal `json:"exchange_rate" swaggertype:"string"`
+}
+
+type GetRateHistory struct {
+	CountryCurrencyDesc string          `json:"country_currency_desc"`
+	RecordDate          string          `json:"record_date"`
+	EffectiveDate       string          `json:"effective_date"`
+	ExchangeRate        decimal.Decimal `json:"exchange_rate" swaggertype:"string"`
+}
+
+/*****
+struct for gets
+******/
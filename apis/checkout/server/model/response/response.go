@@ -13,6 +13,10 @@ type Created struct {
 	ID int64 `json:"id"`
 }
 
+type Updated struct {
+	ID int64 `json:"id"`
+}
+
 type Exception struct {
 	Key     string `json:"key"`
 	Message string `json:"message"`
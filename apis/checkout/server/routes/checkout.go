@@ -1,19 +1,25 @@
 package routes
 
 import (
+	"encoding/json"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 	"github.com/jinzhu/copier"
 	"github.com/luancpereira/APICheckout/apis/checkout/server/model/request"
 	"github.com/luancpereira/APICheckout/apis/checkout/server/model/response"
 	coreError "github.com/luancpereira/APICheckout/core/errors"
 	"github.com/luancpereira/APICheckout/core/service"
+	"github.com/shopspring/decimal"
 )
 
-type Checkout struct{}
+type Checkout struct {
+	Service service.Checkout
+}
 
 /*****
 funcs for posts
@@ -26,29 +32,261 @@ funcs for posts
 //	@Param		body	body		request.InsertTransaction	true	"Body JSON"
 //	@Success	201		{object}	response.Created
 //	@Failure	400		{object}	response.Exception
+//	@Failure	404		{object}	response.Exception
 //	@Router		/api/checkout [post]
-func (Checkout) InsertTransaction(ctx *gin.Context) {
+func (c Checkout) InsertTransaction(ctx *gin.Context) {
 	var req request.InsertTransaction
 	err := GetBody(ctx, &req)
 	if err != nil {
 		return
 	}
 
-	ID, err := service.Checkout{}.CreateTransaction(req.Description, req.TransactionDate, req.TransactionValue)
+	var items []service.TransactionItem
+	err = copier.Copy(&items, req.Items)
 	if err != nil {
 		ResponseBadRequest(ctx, err)
 		return
 	}
 
+	var ID int64
+	if req.QuoteID != 0 {
+		ID, err = c.Service.CreateTransactionWithQuote(req.Description, req.TransactionDate, req.TransactionValue, req.Currency, req.QuoteID, items, req.CustomerID)
+	} else {
+		ID, err = c.Service.CreateTransactionWithItems(req.Description, req.TransactionDate, req.TransactionValue, req.Currency, items, req.CustomerID)
+	}
+
+	if err != nil {
+		ResponseError(ctx, err)
+		return
+	}
+
 	ResponseCreated(ctx, ID)
 }
 
+// godoc
+//
+//	@Tags		Checkout Orders
+//	@Produce	json
+//	@Param		body	body		request.InsertQuote	true	"Body JSON, amount in USD"
+//	@Success	201		{object}	response.Quote
+//	@Failure	400		{object}	response.Exception
+//	@Router		/api/checkout/quotes [post]
+func (c Checkout) InsertQuote(ctx *gin.Context) {
+	var req request.InsertQuote
+	err := GetBody(ctx, &req)
+	if err != nil {
+		return
+	}
+
+	model, err := c.Service.CreateQuote(req.Amount, req.Currency)
+	if err != nil {
+		ResponseBadRequest(ctx, err)
+		return
+	}
+
+	var res response.Quote
+	err = copier.Copy(&res, model)
+	if err != nil {
+		ResponseBadRequest(ctx, err)
+		return
+	}
+
+	ResponseCreatedBody(ctx, res)
+}
+
+// godoc
+//
+//	@Tags		Checkout Rates
+//	@Produce	json
+//	@Param		body				body		request.ConvertBatch	true	"Body JSON, amounts in from (default USD) converted to currency on date (default today)"
+//	@Param		rate_policy			query		string					false	"rate selection: on-or-before, strictly-before, nearest or exact"
+//	@Param		rate_max_age_days	query		int32					false	"maximum distance in days between each rate and the date, 0 for no limit"
+//	@Success	200					{object}	response.List{data=[]response.Converted}
+//	@Failure	400					{object}	response.Exception
+//	@Router		/api/checkout/convert [post]
+func (c Checkout) ConvertBatch(ctx *gin.Context) {
+	var req request.ConvertBatch
+	err := GetBody(ctx, &req)
+	if err != nil {
+		return
+	}
+
+	c.Service.RatePolicy, err = GetRatePolicy(ctx)
+	if err != nil {
+		return
+	}
+
+	var items []service.ConvertItem
+	err = copier.Copy(&items, req.Items)
+	if err != nil {
+		ResponseBadRequest(ctx, err)
+		return
+	}
+
+	models, err := c.Service.ConvertBatch(items)
+	if err != nil {
+		ResponseBadRequest(ctx, err)
+		return
+	}
+
+	res := []response.Converted{}
+	for _, model := range models {
+		converted, errConvert := toConvertedResponse(model)
+		if errConvert != nil {
+			ResponseBadRequest(ctx, errConvert)
+			return
+		}
+
+		res = append(res, converted)
+	}
+
+	ResponseListOk(ctx, res, int64(len(res)))
+}
+
+// godoc
+//
+//	@Tags		Checkout Orders
+//	@Produce	json
+//	@Param		transactionID	path		int64	true	"transactionID"
+//	@Success	200				{object}	response.Updated
+//	@Failure	400				{object}	response.Exception
+//	@Failure	404				{object}	response.Exception
+//	@Router		/api/checkout/transactions/{transactionID}/restore [post]
+func (c Checkout) RestoreTransaction(ctx *gin.Context) {
+	transactionID, err := GetPathParamInt64(ctx, "transactionID", true)
+	if err != nil {
+		return
+	}
+
+	err = c.Service.RestoreTransaction(transactionID)
+	if err != nil {
+		ResponseError(ctx, err)
+		return
+	}
+
+	ResponseOK(ctx, response.Updated{ID: transactionID})
+}
+
+// godoc
+//
+//	@Tags		Checkout Orders
+//	@Produce	json
+//	@Param		transactionID	path		int64					true	"transactionID"
+//	@Param		body			body		request.InsertRefund	true	"Body JSON, amount in the order currency and refund_date defaulting to now"
+//	@Success	201				{object}	response.Created
+//	@Failure	400				{object}	response.Exception
+//	@Failure	404				{object}	response.Exception
+//	@Router		/api/checkout/transactions/{transactionID}/refunds [post]
+func (c Checkout) InsertRefund(ctx *gin.Context) {
+	transactionID, err := GetPathParamInt64(ctx, "transactionID", true)
+	if err != nil {
+		return
+	}
+
+	var req request.InsertRefund
+	err = GetBody(ctx, &req)
+	if err != nil {
+		return
+	}
+
+	ID, err := c.Service.CreateRefund(transactionID, req.Amount, req.Reason, req.RefundDate)
+	if err != nil {
+		ResponseError(ctx, err)
+		return
+	}
+
+	ResponseCreated(ctx, ID)
+}
+
+// godoc
+//
+//	@Tags		Checkout Orders
+//	@Produce	json
+//	@Param		transactionID	path		int64						true	"transactionID"
+//	@Param		body			body		request.TransitionStatus	true	"Body JSON, status pending, paid, cancelled or refunded"
+//	@Success	201				{object}	response.StatusTransition
+//	@Failure	400				{object}	response.Exception
+//	@Failure	404				{object}	response.Exception
+//	@Router		/api/checkout/transactions/{transactionID}/transitions [post]
+func (c Checkout) TransitionStatus(ctx *gin.Context) {
+	transactionID, err := GetPathParamInt64(ctx, "transactionID", true)
+	if err != nil {
+		return
+	}
+
+	var req request.TransitionStatus
+	err = GetBody(ctx, &req)
+	if err != nil {
+		return
+	}
+
+	model, err := c.Service.TransitionTransaction(transactionID, req.Status, req.Reason)
+	if err != nil {
+		ResponseError(ctx, err)
+		return
+	}
+
+	var res response.StatusTransition
+	err = copier.Copy(&res, model)
+	if err != nil {
+		ResponseBadRequest(ctx, err)
+		return
+	}
+
+	ResponseCreatedBody(ctx, res)
+}
+
 /*****
 funcs for posts
 ******/
 
 /*****
-funcs for gets
+funcs for patches
+******/
+
+// godoc
+//
+//	@Tags		Checkout Orders
+//	@Produce	json
+//	@Param		transactionID	path		int64						true	"transactionID"
+//	@Param		body			body		request.UpdateTransaction	true	"Body JSON, only the fields to change"
+//	@Success	200				{object}	response.Updated
+//	@Failure	400				{object}	response.Exception
+//	@Failure	404				{object}	response.Exception
+//	@Router		/api/checkout/transactions/{transactionID} [patch]
+func (c Checkout) UpdateTransaction(ctx *gin.Context) {
+	transactionID, err := GetPathParamInt64(ctx, "transactionID", true)
+	if err != nil {
+		return
+	}
+
+	var req request.UpdateTransaction
+	err = GetBodyStrict(ctx, &req)
+	if err != nil {
+		return
+	}
+
+	update := service.TransactionUpdate{
+		Description:      req.Description,
+		TransactionDate:  req.TransactionDate,
+		TransactionValue: req.TransactionValue,
+	}
+
+	err = c.Service.UpdateTransaction(transactionID, update)
+	if err != nil {
+		ResponseError(ctx, err)
+		return
+	}
+
+	ResponseOK(ctx, response.Updated{ID: transactionID})
+}
+
+/*****
+funcs for patches
+******/
+
+/*****
+funcs for deletes
 ******/
 
 // godoc
@@ -56,11 +294,49 @@ funcs for gets
 //	@Tags		Checkout Orders
 //	@Produce	json
 //	@Param		transactionID	path		int64	true	"transactionID"
-//	@Param		country			path		string	true	"country"
-//	@Success	200				{object}	response.GetTransactionsByID
+//	@Success	200				{object}	response.Updated
 //	@Failure	400				{object}	response.Exception
+//	@Failure	404				{object}	response.Exception
+//	@Router		/api/checkout/transactions/{transactionID} [delete]
+func (c Checkout) DeleteTransaction(ctx *gin.Context) {
+	transactionID, err := GetPathParamInt64(ctx, "transactionID", true)
+	if err != nil {
+		return
+	}
+
+	err = c.Service.DeleteTransaction(transactionID)
+	if err != nil {
+		ResponseError(ctx, err)
+		return
+	}
+
+	ResponseOK(ctx, response.Updated{ID: transactionID})
+}
+
+/*****
+funcs for deletes
+******/
+
+/*****
+funcs for gets
+******/
+
+// godoc
+//
+//	@Tags		Checkout Orders
+//	@Produce	json
+//	@Param		transactionID			path		int64	true	"transactionID"
+//	@Param		country					path		string	true	"country name (English or Portuguese), ISO 4217 currency code, ISO 3166 country code or Treasury country_currency_desc"
+//	@Param		currencies				query		string	false	"comma separated extra currencies, e.g. Brazil,Canada,JPY"
+//	@Param		rate_policy				query		string	false	"rate selection: on-or-before, strictly-before, nearest or exact"
+//	@Param		rate_max_age_days		query		int32	false	"maximum distance in days between the rate and the transaction date, 0 for no limit"
+//	@Param		refresh					query		bool	false	"recompute the conversions instead of returning the stored ones"
+//	@Param		filter_include_deleted	query		bool	false	"include deleted orders"
+//	@Success	200						{object}	response.GetTransactionsByID
+//	@Failure	400						{object}	response.Exception
+//	@Failure	404						{object}	response.Exception
 //	@Router		/api/checkout/transactions/{transactionID}/country/{country} [get]
-func (Checkout) GetByID(ctx *gin.Context) {
+func (c Checkout) GetByID(ctx *gin.Context) {
 	transactionID, err := GetPathParamInt64(ctx, "transactionID", true)
 	if err != nil {
 		return
@@ -71,9 +347,26 @@ func (Checkout) GetByID(ctx *gin.Context) {
 		return
 	}
 
-	model, err := service.Checkout{}.GetByID(transactionID, country)
+	currencies := GetQueryParamList(ctx, "currencies")
+
+	c.Service.RatePolicy, err = GetRatePolicy(ctx)
 	if err != nil {
-		ResponseBadRequest(ctx, err)
+		return
+	}
+
+	c.Service.Refresh, err = GetQueryParamBool(ctx, "refresh")
+	if err != nil {
+		return
+	}
+
+	c.Service.IncludeDeleted, err = GetQueryParamBool(ctx, "filter_include_deleted")
+	if err != nil {
+		return
+	}
+
+	model, err := c.Service.GetByID(transactionID, country, currencies)
+	if err != nil {
+		ResponseError(ctx, err)
 		return
 	}
 
@@ -91,14 +384,57 @@ func (Checkout) GetByID(ctx *gin.Context) {
 //
 //	@Tags		Checkout Orders
 //	@Produce	json
-//	@Param		country					path		string	true	"country"
+//	@Param		transactionID			path		int64	true	"transactionID"
+//	@Param		filter_include_deleted	query		bool	false	"include deleted orders"
+//	@Success	200						{object}	response.List{data=[]response.StatusTransition}
+//	@Failure	400						{object}	response.Exception
+//	@Failure	404						{object}	response.Exception
+//	@Router		/api/checkout/transactions/{transactionID}/transitions [get]
+func (c Checkout) GetStatusHistory(ctx *gin.Context) {
+	transactionID, err := GetPathParamInt64(ctx, "transactionID", true)
+	if err != nil {
+		return
+	}
+
+	c.Service.IncludeDeleted, err = GetQueryParamBool(ctx, "filter_include_deleted")
+	if err != nil {
+		return
+	}
+
+	models, err := c.Service.GetStatusHistory(transactionID)
+	if err != nil {
+		ResponseError(ctx, err)
+		return
+	}
+
+	res := []response.StatusTransition{}
+	err = copier.Copy(&res, models)
+	if err != nil {
+		ResponseBadRequest(ctx, err)
+		return
+	}
+
+	ResponseListOk(ctx, res, int64(len(res)))
+}
+
+// godoc
+//
+//	@Tags		Checkout Orders
+//	@Produce	json
+//	@Param		country					path		string	true	"country name (English or Portuguese), ISO 4217 currency code, ISO 3166 country code or Treasury country_currency_desc"
 //	@Param		limit					query		int32	false	"limit min 1"	default(10)
 //	@Param		offset					query		int32	false	"offset min 0"	default(0)
 //	@Param		filter_transaction_date	query		string	true	"filter_transaction_date"
+//	@Param		currencies				query		string	false	"comma separated extra currencies, e.g. Brazil,Canada,JPY"
+//	@Param		rate_policy				query		string	false	"rate selection: on-or-before, strictly-before, nearest or exact"
+//	@Param		rate_max_age_days		query		int32	false	"maximum distance in days between the rate and the transaction date, 0 for no limit"
+//	@Param		refresh					query		bool	false	"recompute the conversions instead of returning the stored ones"
+//	@Param		filter_include_deleted	query		bool	false	"include deleted orders"
+//	@Param		filter_status			query		string	false	"pending, paid, cancelled or refunded"
 //	@Success	200						{object}	response.List{data=[]response.GetTransactions}
 //	@Failure	400						{object}	response.Exception
 //	@Router		/api/checkout/transactions/country/{country} [get]
-func (Checkout) GetList(ctx *gin.Context) {
+func (c Checkout) GetList(ctx *gin.Context) {
 	country, err := GetPathParamString(ctx, "country", true)
 	if err != nil {
 		return
@@ -111,35 +447,179 @@ func (Checkout) GetList(ctx *gin.Context) {
 		return
 	}
 
-	models, total, err := service.Checkout{}.GetList(filters, limit, offset, country)
+	currencies := GetQueryParamList(ctx, "currencies")
+
+	c.Service.RatePolicy, err = GetRatePolicy(ctx)
+	if err != nil {
+		return
+	}
+
+	c.Service.Refresh, err = GetQueryParamBool(ctx, "refresh")
+	if err != nil {
+		return
+	}
+
+	c.Service.IncludeDeleted, err = GetQueryParamBool(ctx, "filter_include_deleted")
+	if err != nil {
+		return
+	}
+
+	models, total, err := c.Service.GetList(filters, limit, offset, country, currencies)
 	if err != nil {
 		ResponseBadRequest(ctx, err)
 		return
 	}
 
-	var res []response.GetTransactions
+	res, err := toTransactionsResponse(models)
+	if err != nil {
+		ResponseBadRequest(ctx, err)
+		return
+	}
+
+	ResponseListOk(ctx, res, total)
+}
+
+// godoc
+//
+//	@Tags		Checkout Rates
+//	@Produce	json
+//	@Param		amount				query		string	true	"amount to convert, e.g. 1250.00"
+//	@Param		country				query		string	true	"target currency: country name (English or Portuguese), ISO 4217 currency code, ISO 3166 country code or Treasury country_currency_desc"
+//	@Param		date				query		string	false	"date of the rates, YYYY-MM-DD, today when empty"
+//	@Param		from				query		string	false	"currency of the amount, USD when empty"
+//	@Param		rate_policy			query		string	false	"rate selection: on-or-before, strictly-before, nearest or exact"
+//	@Param		rate_max_age_days	query		int32	false	"maximum distance in days between each rate and the date, 0 for no limit"
+//	@Success	200					{object}	response.Converted
+//	@Failure	400					{object}	response.Exception
+//	@Router		/api/checkout/convert [get]
+func (c Checkout) Convert(ctx *gin.Context) {
+	amount, err := decimal.NewFromString(ctx.Query("amount"))
+	if err != nil {
+		ResponseBadRequest(ctx, coreError.New("error.request.query.param.invalid", "amount"))
+		return
+	}
+
+	c.Service.RatePolicy, err = GetRatePolicy(ctx)
+	if err != nil {
+		return
+	}
+
+	item := service.ConvertItem{
+		Amount:   amount,
+		Date:     ctx.Query("date"),
+		From:     ctx.Query("from"),
+		Currency: ctx.Query("country"),
+	}
+
+	model, err := c.Service.Convert(item)
+	if err != nil {
+		ResponseBadRequest(ctx, err)
+		return
+	}
+
+	res, err := toConvertedResponse(model)
+	if err != nil {
+		ResponseBadRequest(ctx, err)
+		return
+	}
+
+	ResponseOK(ctx, res)
+}
+
+// godoc
+//
+//	@Tags		Checkout Rates
+//	@Produce	json
+//	@Param		from				query		string	true	"source currency: country name (English or Portuguese), ISO 4217 currency code, ISO 3166 country code or Treasury country_currency_desc"
+//	@Param		to					query		string	true	"target currency, same formats as from"
+//	@Param		date				query		string	false	"date of the rates, YYYY-MM-DD, today when empty"
+//	@Param		rate_policy			query		string	false	"rate selection: on-or-before, strictly-before, nearest or exact"
+//	@Param		rate_max_age_days	query		int32	false	"maximum distance in days between each rate and the date, 0 for no limit"
+//	@Success	200					{object}	response.CrossRate
+//	@Failure	400					{object}	response.Exception
+//	@Router		/api/checkout/cross-rates [get]
+func (c Checkout) GetCrossRate(ctx *gin.Context) {
+	date, err := GetQueryParamDate(ctx, "date")
+	if err != nil {
+		return
+	}
+
+	c.Service.RatePolicy, err = GetRatePolicy(ctx)
+	if err != nil {
+		return
+	}
+
+	model, err := c.Service.GetCrossRate(ctx.Query("from"), ctx.Query("to"), date)
+	if err != nil {
+		ResponseBadRequest(ctx, err)
+		return
+	}
+
+	var res response.CrossRate
+	err = copier.Copy(&res, model)
+	if err != nil {
+		ResponseBadRequest(ctx, err)
+		return
+	}
+
+	ResponseOK(ctx, res)
+}
+
+/*****
+funcs for gets
+******/
+
+/*****
+other funcs
+******/
 
+func toTransactionsResponse(models []service.TransactionDetailList) (res []response.GetTransactions, err error) {
 	for _, model := range models {
+		var conversions []response.Conversion
+		err = copier.Copy(&conversions, model.Conversions)
+		if err != nil {
+			return
+		}
+
+		var legs response.RateLegs
+		err = copier.Copy(&legs, model.Legs)
+		if err != nil {
+			return
+		}
+
 		res = append(res, response.GetTransactions{
 			ID:                                      model.ID,
 			Description:                             model.Description,
 			TransactionDate:                         model.TransactionDate,
 			TransactionValue:                        model.TransactionValue,
+			Currency:                                model.Currency,
+			TransactionValueUsd:                     model.TransactionValueUsd,
 			ExchangeRate:                            model.ExchangeRate,
 			TransactionValueConvertedToWishCurrency: model.TransactionValueConvertedToWishCurrency,
+			Legs:                                    legs,
+			Stale:                                   model.Stale,
+			Status:                                  model.Status,
+			CustomerID:                              model.CustomerID,
+			Deleted:                                 model.Deleted,
+			Conversions:                             conversions,
 		})
 	}
 
-	ResponseListOk(ctx, res, total)
+	return
 }
 
-/*****
-funcs for gets
-******/
+func toConvertedResponse(model service.ConvertedAmount) (res response.Converted, err error) {
+	err = copier.Copy(&res, model)
+	if err != nil {
+		return
+	}
 
-/*****
-other funcs
-******/
+	if model.Error != nil {
+		res.Legs = nil
+	}
+
+	return
+}
 
 func GetBody(ctx *gin.Context, obj any) (err error) {
 	err = ParseBody(ctx, obj)
@@ -161,6 +641,36 @@ func ParseBody(ctx *gin.Context, obj any) (err error) {
 	return
 }
 
+// GetBodyStrict is GetBody rejecting fields that obj does not declare.
+func GetBodyStrict(ctx *gin.Context, obj any) (err error) {
+	err = ParseBodyStrict(ctx, obj)
+	if err != nil {
+		ResponseBadRequest(ctx, err)
+		return
+	}
+
+	return
+}
+
+// ParseBodyStrict decodes the body as ShouldBindJSON does, binding tags
+// included, but fails on fields that obj does not declare.
+func ParseBodyStrict(ctx *gin.Context, obj any) (err error) {
+	decoder := json.NewDecoder(ctx.Request.Body)
+	decoder.DisallowUnknownFields()
+
+	err = decoder.Decode(obj)
+	if err == nil && binding.Validator != nil {
+		err = binding.Validator.ValidateStruct(obj)
+	}
+
+	if err != nil {
+		err = coreError.New("error.request.body.invalid", err.Error())
+		return
+	}
+
+	return
+}
+
 func GetQueryParam(ctx *gin.Context) (filters map[string]string, sorts map[string]string, limit, offset int64) {
 	filters = make(map[string]string)
 	sorts = make(map[string]string)
@@ -190,6 +700,77 @@ func GetQueryParam(ctx *gin.Context) (filters map[string]string, sorts map[strin
 	return
 }
 
+// GetQueryParamList splits a comma separated query parameter, ignoring blank
+// and repeated values.
+func GetQueryParamList(ctx *gin.Context, key string) (values []string) {
+	seen := make(map[string]bool)
+
+	for _, value := range strings.Split(ctx.Query(key), ",") {
+		value = strings.TrimSpace(value)
+		if len(value) == 0 || seen[strings.ToLower(value)] {
+			continue
+		}
+
+		seen[strings.ToLower(value)] = true
+		values = append(values, value)
+	}
+
+	return
+}
+
+func GetQueryParamBool(ctx *gin.Context, key string) (value bool, err error) {
+	param := ctx.Query(key)
+	if len(param) == 0 {
+		return
+	}
+
+	value, err = strconv.ParseBool(param)
+	if err != nil {
+		err = coreError.New("error.request.query.param.invalid", key)
+		ResponseBadRequest(ctx, err)
+	}
+
+	return
+}
+
+// GetQueryParamDate parses a query parameter in the 2006-01-02 layout,
+// returning today when it is empty.
+func GetQueryParamDate(ctx *gin.Context, key string) (value time.Time, err error) {
+	param := ctx.Query(key)
+	if len(param) == 0 {
+		value = time.Now()
+		return
+	}
+
+	value, err = time.Parse("2006-01-02", param)
+	if err != nil {
+		err = coreError.New("error.request.query.param.invalid", key)
+		ResponseBadRequest(ctx, err)
+	}
+
+	return
+}
+
+// GetRatePolicy overrides the rate policy of the deployment with the
+// rate_policy and rate_max_age_days query parameters. It returns nil when
+// neither is present.
+func GetRatePolicy(ctx *gin.Context) (policy *service.RatePolicy, err error) {
+	selection, maxAgeDays := ctx.Query("rate_policy"), ctx.Query("rate_max_age_days")
+	if len(selection) == 0 && len(maxAgeDays) == 0 {
+		return
+	}
+
+	overridden, err := service.DefaultRatePolicy().Override(selection, maxAgeDays)
+	if err != nil {
+		ResponseBadRequest(ctx, err)
+		return
+	}
+
+	policy = &overridden
+
+	return
+}
+
 func GetPathParamInt64(ctx *gin.Context, key string, required bool) (value int64, err error) {
 	param, err := GetPathParamString(ctx, key, required)
 	if err != nil {
@@ -240,6 +821,23 @@ func ResponseCreatedBody(ctx *gin.Context, bodyResponse any) {
 	ctx.JSON(http.StatusCreated, bodyResponse)
 }
 
+// ResponseError responds 404 when err reports a missing resource and 400
+// otherwise.
+func ResponseError(ctx *gin.Context, err error) {
+	if service.IsNotFound(err) {
+		ResponseNotFound(ctx, err)
+		return
+	}
+
+	ResponseBadRequest(ctx, err)
+}
+
+func ResponseNotFound(ctx *gin.Context, err interface{}) {
+	errOut := coreError.ConvertTo(err)
+
+	ctx.AbortWithStatusJSON(http.StatusNotFound, errOut)
+}
+
 func ResponseBadRequest(ctx *gin.Context, err interface{}) {
 	errOut := coreError.ConvertTo(err)
 
@@ -0,0 +1,260 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/jinzhu/copier"
+	"github.com/luancpereira/APICheckout/apis/checkout/server/model/request"
+	"github.com/luancpereira/APICheckout/apis/checkout/server/model/response"
+	coreError "github.com/luancpereira/APICheckout/core/errors"
+	"github.com/luancpereira/APICheckout/core/service"
+)
+
+/*****
+funcs for posts
+******/
+
+// godoc
+//
+//	@Tags		Checkout Customers
+//	@Produce	json
+//	@Param		body	body		request.InsertCustomer	true	"Body JSON"
+//	@Success	201		{object}	response.Created
+//	@Failure	400		{object}	response.Exception
+//	@Router		/api/checkout/customers [post]
+func (c Checkout) InsertCustomer(ctx *gin.Context) {
+	var req request.InsertCustomer
+	err := GetBody(ctx, &req)
+	if err != nil {
+		return
+	}
+
+	ID, err := c.Service.CreateCustomer(req.Name, req.Email)
+	if err != nil {
+		ResponseBadRequest(ctx, err)
+		return
+	}
+
+	ResponseCreated(ctx, ID)
+}
+
+/*****
+funcs for posts
+******/
+
+/*****
+funcs for patches
+******/
+
+// godoc
+//
+//	@Tags		Checkout Customers
+//	@Produce	json
+//	@Param		customerID	path		int64					true	"customerID"
+//	@Param		body		body		request.UpdateCustomer	true	"Body JSON, only the fields to change"
+//	@Success	200			{object}	response.Updated
+//	@Failure	400			{object}	response.Exception
+//	@Failure	404			{object}	response.Exception
+//	@Router		/api/checkout/customers/{customerID} [patch]
+func (c Checkout) UpdateCustomer(ctx *gin.Context) {
+	customerID, err := GetPathParamInt64(ctx, "customerID", true)
+	if err != nil {
+		return
+	}
+
+	var req request.UpdateCustomer
+	err = GetBodyStrict(ctx, &req)
+	if err != nil {
+		return
+	}
+
+	update := service.CustomerUpdate{
+		Name:  req.Name,
+		Email: req.Email,
+	}
+
+	err = c.Service.UpdateCustomer(customerID, update)
+	if err != nil {
+		ResponseError(ctx, err)
+		return
+	}
+
+	ResponseOK(ctx, response.Updated{ID: customerID})
+}
+
+/*****
+funcs for patches
+******/
+
+/*****
+funcs for deletes
+******/
+
+// godoc
+//
+//	@Tags		Checkout Customers
+//	@Produce	json
+//	@Param		customerID	path		int64	true	"customerID"
+//	@Success	200			{object}	response.Updated
+//	@Failure	400			{object}	response.Exception
+//	@Failure	404			{object}	response.Exception
+//	@Router		/api/checkout/customers/{customerID} [delete]
+func (c Checkout) DeleteCustomer(ctx *gin.Context) {
+	customerID, err := GetPathParamInt64(ctx, "customerID", true)
+	if err != nil {
+		return
+	}
+
+	err = c.Service.DeleteCustomer(customerID)
+	if err != nil {
+		ResponseError(ctx, err)
+		return
+	}
+
+	ResponseOK(ctx, response.Updated{ID: customerID})
+}
+
+/*****
+funcs for deletes
+******/
+
+/*****
+funcs for gets
+******/
+
+// godoc
+//
+//	@Tags		Checkout Customers
+//	@Produce	json
+//	@Param		customerID	path		int64	true	"customerID"
+//	@Success	200			{object}	response.Customer
+//	@Failure	400			{object}	response.Exception
+//	@Failure	404			{object}	response.Exception
+//	@Router		/api/checkout/customers/{customerID} [get]
+func (c Checkout) GetCustomer(ctx *gin.Context) {
+	customerID, err := GetPathParamInt64(ctx, "customerID", true)
+	if err != nil {
+		return
+	}
+
+	model, err := c.Service.GetCustomer(customerID)
+	if err != nil {
+		ResponseError(ctx, err)
+		return
+	}
+
+	var res response.Customer
+	err = copier.Copy(&res, model)
+	if err != nil {
+		ResponseBadRequest(ctx, err)
+		return
+	}
+
+	ResponseOK(ctx, res)
+}
+
+// godoc
+//
+//	@Tags		Checkout Customers
+//	@Produce	json
+//	@Param		limit	query		int32	false	"limit min 1"	default(10)
+//	@Param		offset	query		int32	false	"offset min 0"	default(0)
+//	@Success	200		{object}	response.List{data=[]response.Customer}
+//	@Failure	400		{object}	response.Exception
+//	@Router		/api/checkout/customers [get]
+func (c Checkout) GetCustomers(ctx *gin.Context) {
+	_, _, limit, offset := GetQueryParam(ctx)
+
+	models, total, err := c.Service.GetCustomers(limit, offset)
+	if err != nil {
+		ResponseBadRequest(ctx, err)
+		return
+	}
+
+	res := []response.Customer{}
+	err = copier.Copy(&res, models)
+	if err != nil {
+		ResponseBadRequest(ctx, err)
+		return
+	}
+
+	ResponseListOk(ctx, res, total)
+}
+
+// godoc
+//
+//	@Tags		Checkout Customers
+//	@Produce	json
+//	@Param		customerID				path		int64	true	"customerID"
+//	@Param		country					query		string	true	"country name (English or Portuguese), ISO 4217 currency code, ISO 3166 country code or Treasury country_currency_desc"
+//	@Param		limit					query		int32	false	"limit min 1"	default(10)
+//	@Param		offset					query		int32	false	"offset min 0"	default(0)
+//	@Param		filter_transaction_date	query		string	false	"filter_transaction_date"
+//	@Param		filter_status			query		string	false	"pending, paid, cancelled or refunded"
+//	@Param		currencies				query		string	false	"comma separated extra currencies, e.g. Brazil,Canada,JPY"
+//	@Param		rate_policy				query		string	false	"rate selection: on-or-before, strictly-before, nearest or exact"
+//	@Param		rate_max_age_days		query		int32	false	"maximum distance in days between the rate and the transaction date, 0 for no limit"
+//	@Param		refresh					query		bool	false	"recompute the conversions instead of returning the stored ones"
+//	@Param		filter_include_deleted	query		bool	false	"include deleted orders"
+//	@Success	200						{object}	response.CustomerTransactions
+//	@Failure	400						{object}	response.Exception
+//	@Failure	404						{object}	response.Exception
+//	@Router		/api/checkout/customers/{customerID}/transactions [get]
+func (c Checkout) GetCustomerTransactions(ctx *gin.Context) {
+	customerID, err := GetPathParamInt64(ctx, "customerID", true)
+	if err != nil {
+		return
+	}
+
+	country := ctx.Query("country")
+	if !coreError.StringIsNotEmpty(country) {
+		ResponseBadRequest(ctx, coreError.New("error.request.query.param.invalid", "country"))
+		return
+	}
+
+	filters, _, limit, offset := GetQueryParam(ctx)
+	currencies := GetQueryParamList(ctx, "currencies")
+
+	c.Service.RatePolicy, err = GetRatePolicy(ctx)
+	if err != nil {
+		return
+	}
+
+	c.Service.Refresh, err = GetQueryParamBool(ctx, "refresh")
+	if err != nil {
+		return
+	}
+
+	c.Service.IncludeDeleted, err = GetQueryParamBool(ctx, "filter_include_deleted")
+	if err != nil {
+		return
+	}
+
+	models, total, totals, err := c.Service.GetCustomerTransactions(customerID, filters, limit, offset, country, currencies)
+	if err != nil {
+		ResponseError(ctx, err)
+		return
+	}
+
+	data, err := toTransactionsResponse(models)
+	if err != nil {
+		ResponseBadRequest(ctx, err)
+		return
+	}
+
+	res := response.CustomerTransactions{
+		Pagination: response.Pagination{Total: total},
+		Data:       data,
+	}
+
+	err = copier.Copy(&res.Totals, totals)
+	if err != nil {
+		ResponseBadRequest(ctx, err)
+		return
+	}
+
+	ResponseOK(ctx, res)
+}
+
+/*****
+funcs for gets
+******/
@@ -0,0 +1,83 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/jinzhu/copier"
+	"github.com/luancpereira/APICheckout/apis/checkout/server/model/response"
+	"github.com/luancpereira/APICheckout/core/service"
+)
+
+type Rates struct {
+	Service service.Rates
+}
+
+/*****
+funcs for gets
+******/
+
+// godoc
+//
+//	@Tags		Checkout Rates
+//	@Produce	json
+//	@Param		filter_name	query		string	false	"prefix of the country, currency or country_currency_desc"
+//	@Success	200			{object}	response.List{data=[]response.GetCurrencies}
+//	@Failure	400			{object}	response.Exception
+//	@Router		/api/checkout/currencies [get]
+func (r Rates) GetCurrencies(ctx *gin.Context) {
+	filters, _, _, _ := GetQueryParam(ctx)
+
+	models, err := r.Service.GetCurrencies(filters)
+	if err != nil {
+		ResponseBadRequest(ctx, err)
+		return
+	}
+
+	res := []response.GetCurrencies{}
+	err = copier.Copy(&res, models)
+	if err != nil {
+		ResponseBadRequest(ctx, err)
+		return
+	}
+
+	ResponseListOk(ctx, res, int64(len(res)))
+}
+
+// godoc
+//
+//	@Tags		Checkout Rates
+//	@Produce	json
+//	@Param		country	path		string	true	"country name (English or Portuguese), ISO 4217 currency code, ISO 3166 country code or Treasury country_currency_desc"
+//	@Param		from	query		string	false	"first effective date, YYYY-MM-DD"
+//	@Param		to		query		string	false	"last effective date, YYYY-MM-DD"
+//	@Param		limit	query		int32	false	"limit min 1"	default(10)
+//	@Param		offset	query		int32	false	"offset min 0"	default(0)
+//	@Success	200		{object}	response.List{data=[]response.GetRateHistory}
+//	@Failure	400		{object}	response.Exception
+//	@Router		/api/checkout/rates/{country} [get]
+func (r Rates) GetHistory(ctx *gin.Context) {
+	country, err := GetPathParamString(ctx, "country", true)
+	if err != nil {
+		return
+	}
+
+	_, _, limit, offset := GetQueryParam(ctx)
+
+	models, total, err := r.Service.GetHistory(country, ctx.Query("from"), ctx.Query("to"), limit, offset)
+	if err != nil {
+		ResponseBadRequest(ctx, err)
+		return
+	}
+
+	res := []response.GetRateHistory{}
+	err = copier.Copy(&res, models)
+	if err != nil {
+		ResponseBadRequest(ctx, err)
+		return
+	}
+
+	ResponseListOk(ctx, res, total)
+}
+
+/*****
+funcs for gets
+******/
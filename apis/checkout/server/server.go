@@ -4,6 +4,8 @@ import (
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"github.com/luancpereira/APICheckout/apis/checkout/server/routes"
+	"github.com/luancpereira/APICheckout/core/currency"
+	"github.com/luancpereira/APICheckout/core/service"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 )
@@ -52,10 +54,48 @@ func (s Server) Start() {
 func (s Server) setupRouterV1() {
 	freeRoutes := s.Router.Group("")
 
-	checkout := routes.Checkout{}
+	err := service.LoadRatePolicy()
+	if err != nil {
+		panic(err)
+	}
+
+	err = service.LoadRateStaleAfterDays()
+	if err != nil {
+		panic(err)
+	}
+
+	rateProvider, err := service.NewExchangeRateProvider()
+	if err != nil {
+		panic(err)
+	}
+
+	currency.Lookup = rateProvider.FindCurrency
+
+	checkout := routes.Checkout{Service: service.Checkout{RateProvider: rateProvider}}
+	rates := routes.Rates{Service: service.Rates{Provider: rateProvider}}
 
 	freeRoutes.POST("/api/checkout", checkout.InsertTransaction)
+	freeRoutes.POST("/api/checkout/quotes", checkout.InsertQuote)
 	freeRoutes.GET("/api/checkout/transactions/country/:country", checkout.GetList)
 	freeRoutes.GET("/api/checkout/transactions/:transactionID/country/:country", checkout.GetByID)
+	freeRoutes.PATCH("/api/checkout/transactions/:transactionID", checkout.UpdateTransaction)
+	freeRoutes.DELETE("/api/checkout/transactions/:transactionID", checkout.DeleteTransaction)
+	freeRoutes.POST("/api/checkout/transactions/:transactionID/restore", checkout.RestoreTransaction)
+	freeRoutes.POST("/api/checkout/transactions/:transactionID/refunds", checkout.InsertRefund)
+	freeRoutes.POST("/api/checkout/transactions/:transactionID/transitions", checkout.TransitionStatus)
+	freeRoutes.GET("/api/checkout/transactions/:transactionID/transitions", checkout.GetStatusHistory)
+
+	freeRoutes.POST("/api/checkout/customers", checkout.InsertCustomer)
+	freeRoutes.GET("/api/checkout/customers", checkout.GetCustomers)
+	freeRoutes.GET("/api/checkout/customers/:customerID", checkout.GetCustomer)
+	freeRoutes.PATCH("/api/checkout/customers/:customerID", checkout.UpdateCustomer)
+	freeRoutes.DELETE("/api/checkout/customers/:customerID", checkout.DeleteCustomer)
+	freeRoutes.GET("/api/checkout/customers/:customerID/transactions", checkout.GetCustomerTransactions)
+
+	freeRoutes.GET("/api/checkout/currencies", rates.GetCurrencies)
+	freeRoutes.GET("/api/checkout/rates/:country", rates.GetHistory)
+	freeRoutes.GET("/api/checkout/cross-rates", checkout.GetCrossRate)
+	freeRoutes.GET("/api/checkout/convert", checkout.Convert)
+	freeRoutes.POST("/api/checkout/convert", checkout.ConvertBatch)
 
 }
@@ -0,0 +1,23 @@
+module github.com/luancpereira/APICheckout/cmd/ratesctl
+
+go 1.22.0
+
+replace github.com/luancpereira/APICheckout/core => ../../core
+
+require (
+	github.com/luancpereira/APICheckout/core v0.0.1
+	github.com/shopspring/decimal v1.4.0
+	github.com/stretchr/testify v1.9.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/jellydator/ttlcache/v3 v3.2.0 // indirect
+	github.com/lib/pq v1.10.9 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	github.com/sirupsen/logrus v1.9.3 // indirect
+	golang.org/x/sync v0.9.0 // indirect
+	golang.org/x/sys v0.27.0 // indirect
+	golang.org/x/text v0.3.3 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
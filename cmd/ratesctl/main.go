@@ -0,0 +1,87 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/luancpereira/APICheckout/core/database"
+	"github.com/luancpereira/APICheckout/core/errors"
+	"github.com/luancpereira/APICheckout/core/service"
+)
+
+const usage = `ratesctl manages the Treasury exchange rates stored in rates_of_exchange.
+
+Usage:
+	ratesctl import <file>	upserts a rates_of_exchange CSV or JSON export on (country, currency, effective_date)
+`
+
+// startDatabase connects to the database the records are imported into,
+// replaced in tests.
+var startDatabase = database.Config{}.Start
+
+// main entrypoint application
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run executes the command in args and returns the exit code, 2 for invalid
+// usage and 1 when the command fails.
+func run(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("ratesctl", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprint(flags.Output(), usage)
+	}
+
+	err := flags.Parse(args)
+	if err == flag.ErrHelp {
+		return 0
+	}
+
+	if err != nil || flags.NArg() < 1 {
+		flags.Usage()
+		return 2
+	}
+
+	switch flags.Arg(0) {
+	case "import":
+		err = Import(flags.Args()[1:], stdout)
+		if err != nil {
+			fmt.Fprintln(stderr, err.Error())
+			return 1
+		}
+	default:
+		flags.Usage()
+		return 2
+	}
+
+	return 0
+}
+
+// Import loads the export given in args into rates_of_exchange and prints to
+// out how many records were inserted, updated and skipped.
+func Import(args []string, out io.Writer) (err error) {
+	if len(args) != 1 {
+		return fmt.Errorf("import expects exactly one file, got %d", len(args))
+	}
+
+	errors.Factory{}.Start()
+
+	records, err := service.LoadRecordsFile(args[0])
+	if err != nil {
+		return
+	}
+
+	startDatabase()
+
+	result, err := service.ExchangeRate{}.Save(records)
+	if err != nil {
+		return
+	}
+
+	fmt.Fprintf(out, "read: %d, inserted: %d, updated: %d, skipped: %d\n", len(records), result.Inserted, result.Updated, result.Skipped)
+
+	return
+}
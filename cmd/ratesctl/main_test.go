@@ -0,0 +1,98 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/luancpereira/APICheckout/core/database"
+	"github.com/luancpereira/APICheckout/core/service/servicetest"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRun(t *testing.T) {
+	t.Run("Deve exibir o uso sem comando", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+
+		code := run(nil, &stdout, &stderr)
+
+		assert.Equal(t, 2, code)
+		assert.Contains(t, stderr.String(), "ratesctl import <file>")
+		assert.Empty(t, stdout.String())
+	})
+
+	t.Run("Deve exibir o uso para comando desconhecido", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+
+		code := run([]string{"export"}, &stdout, &stderr)
+
+		assert.Equal(t, 2, code)
+		assert.Contains(t, stderr.String(), "Usage:")
+	})
+
+	t.Run("Deve exibir o uso com -h", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+
+		code := run([]string{"-h"}, &stdout, &stderr)
+
+		assert.Equal(t, 0, code)
+		assert.Contains(t, stderr.String(), "Usage:")
+	})
+
+	t.Run("Deve exigir exatamente um arquivo no import", func(t *testing.T) {
+		for _, args := range [][]string{{"import"}, {"import", "a.csv", "b.csv"}} {
+			var stdout, stderr bytes.Buffer
+
+			code := run(args, &stdout, &stderr)
+
+			assert.Equal(t, 1, code)
+			assert.Contains(t, stderr.String(), "import expects exactly one file")
+		}
+	})
+
+	t.Run("Deve falhar sem conectar ao banco quando o arquivo não existe", func(t *testing.T) {
+		started := false
+		defer replaceStartDatabase(func() { started = true })()
+
+		var stdout, stderr bytes.Buffer
+
+		code := run([]string{"import", filepath.Join(t.TempDir(), "rates.csv")}, &stdout, &stderr)
+
+		assert.Equal(t, 1, code)
+		assert.NotEmpty(t, stderr.String())
+		assert.False(t, started)
+	})
+}
+
+func TestImport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rates.csv")
+	content := "record_date,country,currency,country_currency_desc,exchange_rate,effective_date\n" +
+		"2024-12-31,Brazil,Real,Brazil-Real,6.192,2024-12-31\n" +
+		"2024-12-31,Mexico,Peso,Mexico-Peso,20.5,2024-12-31\n" +
+		"2024-12-31,Canada,Dollar,Canada-Dollar,1.44,2024-12-31\n" +
+		"invalid,Japan,Yen,Japan-Yen,157.2,2024-12-31\n"
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	defer replaceStartDatabase(func() {
+		database.DB_QUERIER = servicetest.MockQuerier{RatesOfExchange: map[string]decimal.Decimal{
+			"Mexico-Peso2024-12-31":   decimal.RequireFromString("20.1"),
+			"Canada-Dollar2024-12-31": decimal.RequireFromString("1.44"),
+		}}
+	})()
+
+	var out bytes.Buffer
+
+	err := Import([]string{path}, &out)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "read: 4, inserted: 1, updated: 1, skipped: 2\n", out.String())
+}
+
+func replaceStartDatabase(start func()) (restore func()) {
+	previous := startDatabase
+	startDatabase = start
+
+	return func() { startDatabase = previous }
+}
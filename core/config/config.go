@@ -5,5 +5,25 @@ import (
 )
 
 var (
-	ERROR_FILE = os.Getenv("ERROR_FILE")
+	ERROR_FILE            = os.Getenv("ERROR_FILE")
+	RATES_SYNC_ENABLED    = os.Getenv("RATES_SYNC_ENABLED")
+	RATES_SYNC_INTERVAL   = os.Getenv("RATES_SYNC_INTERVAL")
+	RATES_SYNC_START_DATE = os.Getenv("RATES_SYNC_START_DATE")
+	RATE_PROVIDER         = os.Getenv("RATE_PROVIDER")
+	RATE_FILE             = os.Getenv("RATE_FILE")
+	RATE_CACHE_TTL        = os.Getenv("RATE_CACHE_TTL")
+	RATE_CACHE_CAPACITY   = os.Getenv("RATE_CACHE_CAPACITY")
+
+	RATE_POLICY              = os.Getenv("RATE_POLICY")
+	RATE_POLICY_MAX_AGE_DAYS = os.Getenv("RATE_POLICY_MAX_AGE_DAYS")
+	RATE_STALE_AFTER_DAYS    = os.Getenv("RATE_STALE_AFTER_DAYS")
+
+	HTTP_CLIENT_TIMEOUT           = os.Getenv("HTTP_CLIENT_TIMEOUT")
+	HTTP_CLIENT_MAX_RETRIES       = os.Getenv("HTTP_CLIENT_MAX_RETRIES")
+	HTTP_CLIENT_BACKOFF           = os.Getenv("HTTP_CLIENT_BACKOFF")
+	HTTP_CLIENT_BREAKER_THRESHOLD = os.Getenv("HTTP_CLIENT_BREAKER_THRESHOLD")
+	HTTP_CLIENT_BREAKER_COOLDOWN  = os.Getenv("HTTP_CLIENT_BREAKER_COOLDOWN")
+
+	QUOTE_TTL         = os.Getenv("QUOTE_TTL")
+	CONVERT_BATCH_MAX = os.Getenv("CONVERT_BATCH_MAX")
 )
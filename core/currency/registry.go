@@ -0,0 +1,175 @@
+package currency
+
+import (
+	"strings"
+	"unicode"
+
+	coreError "github.com/luancpereira/APICheckout/core/errors"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+const USD = "USD"
+
+// Currency is a currency supported by the Treasury rates_of_exchange dataset.
+// Key is the Treasury country_currency_desc and is used as the canonical key
+// for the lookups; every other field is an alias accepted by Resolve.
+type Currency struct {
+	Key          string
+	Country      string
+	Currency     string
+	Code         string
+	CountryCodes []string
+	Names        []string
+}
+
+var registry = []Currency{
+	{Key: "United States-Dollar", Country: "United States", Currency: "Dollar", Code: USD, CountryCodes: []string{"US", "USA"}, Names: []string{"United States of America", "Estados Unidos", "Estados Unidos da América", "EUA"}},
+	{Key: "Angola-Kwanza", Country: "Angola", Currency: "Kwanza", Code: "AOA", CountryCodes: []string{"AO", "AGO"}},
+	{Key: "Argentina-Peso", Country: "Argentina", Currency: "Peso", Code: "ARS", CountryCodes: []string{"AR", "ARG"}},
+	{Key: "Australia-Dollar", Country: "Australia", Currency: "Dollar", Code: "AUD", CountryCodes: []string{"AU", "AUS"}, Names: []string{"Austrália"}},
+	{Key: "Bolivia-Boliviano", Country: "Bolivia", Currency: "Boliviano", Code: "BOB", CountryCodes: []string{"BO", "BOL"}, Names: []string{"Bolívia"}},
+	{Key: "Brazil-Real", Country: "Brazil", Currency: "Real", Code: "BRL", CountryCodes: []string{"BR", "BRA"}, Names: []string{"Brasil"}},
+	{Key: "Canada-Dollar", Country: "Canada", Currency: "Dollar", Code: "CAD", CountryCodes: []string{"CA", "CAN"}, Names: []string{"Canadá"}},
+	{Key: "Cape Verde-Escudo", Country: "Cape Verde", Currency: "Escudo", Code: "CVE", CountryCodes: []string{"CV", "CPV"}, Names: []string{"Cabo Verde"}},
+	{Key: "Chile-Peso", Country: "Chile", Currency: "Peso", Code: "CLP", CountryCodes: []string{"CL", "CHL"}},
+	{Key: "China-Renminbi", Country: "China", Currency: "Renminbi", Code: "CNY", CountryCodes: []string{"CN", "CHN"}},
+	{Key: "Colombia-Peso", Country: "Colombia", Currency: "Peso", Code: "COP", CountryCodes: []string{"CO", "COL"}, Names: []string{"Colômbia"}},
+	{Key: "Costa Rica-Colon", Country: "Costa Rica", Currency: "Colon", Code: "CRC", CountryCodes: []string{"CR", "CRI"}},
+	{Key: "Czech Republic-Koruna", Country: "Czech Republic", Currency: "Koruna", Code: "CZK", CountryCodes: []string{"CZ", "CZE"}, Names: []string{"Czechia", "República Tcheca", "Tchéquia"}},
+	{Key: "Denmark-Krone", Country: "Denmark", Currency: "Krone", Code: "DKK", CountryCodes: []string{"DK", "DNK"}, Names: []string{"Dinamarca"}},
+	{Key: "Dominican Republic-Peso", Country: "Dominican Republic", Currency: "Peso", Code: "DOP", CountryCodes: []string{"DO", "DOM"}, Names: []string{"República Dominicana"}},
+	{Key: "Egypt-Pound", Country: "Egypt", Currency: "Pound", Code: "EGP", CountryCodes: []string{"EG", "EGY"}, Names: []string{"Egito"}},
+	{Key: "Euro Zone-Euro", Country: "Euro Zone", Currency: "Euro", Code: "EUR",
+		CountryCodes: []string{"EU", "DE", "DEU", "FR", "FRA", "IT", "ITA", "ES", "ESP", "PT", "PRT", "NL", "NLD", "BE", "BEL", "AT", "AUT", "IE", "IRL", "FI", "FIN", "GR", "GRC"},
+		Names:        []string{"Zona do Euro", "Eurozone", "Germany", "Alemanha", "France", "França", "Italy", "Itália", "Spain", "Espanha", "Portugal", "Netherlands", "Holanda", "Países Baixos", "Belgium", "Bélgica", "Austria", "Áustria", "Ireland", "Irlanda", "Finland", "Finlândia", "Greece", "Grécia"}},
+	{Key: "Guatemala-Quetzal", Country: "Guatemala", Currency: "Quetzal", Code: "GTQ", CountryCodes: []string{"GT", "GTM"}},
+	{Key: "Honduras-Lempira", Country: "Honduras", Currency: "Lempira", Code: "HNL", CountryCodes: []string{"HN", "HND"}},
+	{Key: "Hong Kong-Dollar", Country: "Hong Kong", Currency: "Dollar", Code: "HKD", CountryCodes: []string{"HK", "HKG"}},
+	{Key: "Hungary-Forint", Country: "Hungary", Currency: "Forint", Code: "HUF", CountryCodes: []string{"HU", "HUN"}, Names: []string{"Hungria"}},
+	{Key: "Iceland-Krona", Country: "Iceland", Currency: "Krona", Code: "ISK", CountryCodes: []string{"IS", "ISL"}, Names: []string{"Islândia"}},
+	{Key: "India-Rupee", Country: "India", Currency: "Rupee", Code: "INR", CountryCodes: []string{"IN", "IND"}, Names: []string{"Índia"}},
+	{Key: "Indonesia-Rupiah", Country: "Indonesia", Currency: "Rupiah", Code: "IDR", CountryCodes: []string{"ID", "IDN"}, Names: []string{"Indonésia"}},
+	{Key: "Israel-Shekel", Country: "Israel", Currency: "Shekel", Code: "ILS", CountryCodes: []string{"IL", "ISR"}},
+	{Key: "Japan-Yen", Country: "Japan", Currency: "Yen", Code: "JPY", CountryCodes: []string{"JP", "JPN"}, Names: []string{"Japão"}},
+	{Key: "Kenya-Shilling", Country: "Kenya", Currency: "Shilling", Code: "KES", CountryCodes: []string{"KE", "KEN"}, Names: []string{"Quênia"}},
+	{Key: "Korea-Won", Country: "Korea", Currency: "Won", Code: "KRW", CountryCodes: []string{"KR", "KOR"}, Names: []string{"South Korea", "Coreia do Sul", "Coreia"}},
+	{Key: "Malaysia-Ringgit", Country: "Malaysia", Currency: "Ringgit", Code: "MYR", CountryCodes: []string{"MY", "MYS"}, Names: []string{"Malásia"}},
+	{Key: "Mexico-Peso", Country: "Mexico", Currency: "Peso", Code: "MXN", CountryCodes: []string{"MX", "MEX"}, Names: []string{"México"}},
+	{Key: "Morocco-Dirham", Country: "Morocco", Currency: "Dirham", Code: "MAD", CountryCodes: []string{"MA", "MAR"}, Names: []string{"Marrocos"}},
+	{Key: "Mozambique-Metical", Country: "Mozambique", Currency: "Metical", Code: "MZN", CountryCodes: []string{"MZ", "MOZ"}, Names: []string{"Moçambique"}},
+	{Key: "New Zealand-Dollar", Country: "New Zealand", Currency: "Dollar", Code: "NZD", CountryCodes: []string{"NZ", "NZL"}, Names: []string{"Nova Zelândia"}},
+	{Key: "Nigeria-Naira", Country: "Nigeria", Currency: "Naira", Code: "NGN", CountryCodes: []string{"NG", "NGA"}, Names: []string{"Nigéria"}},
+	{Key: "Norway-Krone", Country: "Norway", Currency: "Krone", Code: "NOK", CountryCodes: []string{"NO", "NOR"}, Names: []string{"Noruega"}},
+	{Key: "Pakistan-Rupee", Country: "Pakistan", Currency: "Rupee", Code: "PKR", CountryCodes: []string{"PK", "PAK"}, Names: []string{"Paquistão"}},
+	{Key: "Paraguay-Guarani", Country: "Paraguay", Currency: "Guarani", Code: "PYG", CountryCodes: []string{"PY", "PRY"}, Names: []string{"Paraguai"}},
+	{Key: "Peru-Sol", Country: "Peru", Currency: "Sol", Code: "PEN", CountryCodes: []string{"PE", "PER"}},
+	{Key: "Philippines-Peso", Country: "Philippines", Currency: "Peso", Code: "PHP", CountryCodes: []string{"PH", "PHL"}, Names: []string{"Filipinas"}},
+	{Key: "Poland-Zloty", Country: "Poland", Currency: "Zloty", Code: "PLN", CountryCodes: []string{"PL", "POL"}, Names: []string{"Polônia"}},
+	{Key: "Romania-New Leu", Country: "Romania", Currency: "New Leu", Code: "RON", CountryCodes: []string{"RO", "ROU"}, Names: []string{"Romênia"}},
+	{Key: "Russia-Ruble", Country: "Russia", Currency: "Ruble", Code: "RUB", CountryCodes: []string{"RU", "RUS"}, Names: []string{"Rússia"}},
+	{Key: "Saudi Arabia-Riyal", Country: "Saudi Arabia", Currency: "Riyal", Code: "SAR", CountryCodes: []string{"SA", "SAU"}, Names: []string{"Arábia Saudita"}},
+	{Key: "Singapore-Dollar", Country: "Singapore", Currency: "Dollar", Code: "SGD", CountryCodes: []string{"SG", "SGP"}, Names: []string{"Singapura"}},
+	{Key: "South Africa-Rand", Country: "South Africa", Currency: "Rand", Code: "ZAR", CountryCodes: []string{"ZA", "ZAF"}, Names: []string{"África do Sul"}},
+	{Key: "Sweden-Krona", Country: "Sweden", Currency: "Krona", Code: "SEK", CountryCodes: []string{"SE", "SWE"}, Names: []string{"Suécia"}},
+	{Key: "Switzerland-Franc", Country: "Switzerland", Currency: "Franc", Code: "CHF", CountryCodes: []string{"CH", "CHE"}, Names: []string{"Suíça"}},
+	{Key: "Taiwan-Dollar", Country: "Taiwan", Currency: "Dollar", Code: "TWD", CountryCodes: []string{"TW", "TWN"}},
+	{Key: "Thailand-Baht", Country: "Thailand", Currency: "Baht", Code: "THB", CountryCodes: []string{"TH", "THA"}, Names: []string{"Tailândia"}},
+	{Key: "Turkey-Lira", Country: "Turkey", Currency: "Lira", Code: "TRY", CountryCodes: []string{"TR", "TUR"}, Names: []string{"Türkiye", "Turquia"}},
+	{Key: "Ukraine-Hryvnia", Country: "Ukraine", Currency: "Hryvnia", Code: "UAH", CountryCodes: []string{"UA", "UKR"}, Names: []string{"Ucrânia"}},
+	{Key: "United Arab Emirates-Dirham", Country: "United Arab Emirates", Currency: "Dirham", Code: "AED", CountryCodes: []string{"AE", "ARE"}, Names: []string{"Emirados Árabes Unidos"}},
+	{Key: "United Kingdom-Pound", Country: "United Kingdom", Currency: "Pound", Code: "GBP", CountryCodes: []string{"GB", "GBR", "UK"}, Names: []string{"Reino Unido", "Great Britain", "England", "Inglaterra"}},
+	{Key: "Uruguay-Peso", Country: "Uruguay", Currency: "Peso", Code: "UYU", CountryCodes: []string{"UY", "URY"}, Names: []string{"Uruguai"}},
+	{Key: "Venezuela-Bolivar Soberano", Country: "Venezuela", Currency: "Bolivar Soberano", Code: "VES", CountryCodes: []string{"VE", "VEN"}},
+	{Key: "Vietnam-Dong", Country: "Vietnam", Currency: "Dong", Code: "VND", CountryCodes: []string{"VN", "VNM"}, Names: []string{"Vietnã", "Viet Nam"}},
+}
+
+var index = buildIndex()
+
+// Lookup finds in the rates data the currencies missing from the registry, by
+// their Treasury country_currency_desc or country. It is set at startup to the
+// rate provider; while nil, Resolve only knows the registry.
+var Lookup func(value string) (currency Currency, found bool, err error)
+
+// Resolve finds the currency for any of its accepted forms: the ISO 4217
+// code, an ISO 3166 country code, the English or Portuguese country name or
+// the Treasury country_currency_desc. The match ignores case and accents.
+// Values missing from the registry go through Lookup, and the currencies it
+// finds have no Code.
+func Resolve(value string) (currency Currency, err error) {
+	currency, ok := Registered(value)
+	if !ok && Lookup != nil {
+		currency, ok, err = Lookup(strings.TrimSpace(value))
+		if err != nil {
+			return
+		}
+	}
+
+	if !ok {
+		err = coreError.New("error.currency.not.supported", value)
+		return
+	}
+
+	return
+}
+
+// ResolveCode resolves the value as Resolve does, limited to the currencies
+// with an ISO 4217 code, the only ones orders and quotes are kept in.
+func ResolveCode(value string) (currency Currency, err error) {
+	currency, err = Resolve(value)
+	if err != nil {
+		return
+	}
+
+	if currency.Code == "" {
+		err = coreError.New("error.currency.not.supported", value)
+		return
+	}
+
+	return
+}
+
+// Registered finds the currency in the registry only.
+func Registered(value string) (currency Currency, ok bool) {
+	currency, ok = index[Normalize(value)]
+
+	return
+}
+
+// All returns every supported currency, in registry order.
+func All() []Currency {
+	return append([]Currency(nil), registry...)
+}
+
+func (c Currency) IsUSD() bool {
+	return c.Code == USD
+}
+
+// Normalize lowercases the value, removes its accents and collapses its spaces.
+func Normalize(value string) string {
+	withoutAccents, _, err := transform.String(transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC), value)
+	if err != nil {
+		withoutAccents = value
+	}
+
+	return strings.ToLower(strings.Join(strings.Fields(withoutAccents), " "))
+}
+
+func buildIndex() map[string]Currency {
+	index := make(map[string]Currency)
+
+	for _, currency := range registry {
+		aliases := append([]string{currency.Key, currency.Country, currency.Code}, currency.CountryCodes...)
+		aliases = append(aliases, currency.Names...)
+
+		for _, alias := range aliases {
+			key := Normalize(alias)
+			if _, exists := index[key]; !exists {
+				index[key] = currency
+			}
+		}
+	}
+
+	return index
+}
@@ -0,0 +1,110 @@
+package currency_test
+
+import (
+	"testing"
+
+	"github.com/jellydator/ttlcache/v3"
+	"github.com/luancpereira/APICheckout/core/currency"
+	coreErrors "github.com/luancpereira/APICheckout/core/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMain(m *testing.M) {
+	coreErrors.C = ttlcache.New[string, string]()
+
+	coreErrors.C.Set("error.currency.not.supported", "Currency not supported:", ttlcache.NoTTL)
+
+	m.Run()
+}
+
+func TestResolve(t *testing.T) {
+	t.Run("Deve resolver todas as formas aceitas para a mesma moeda", func(t *testing.T) {
+		for _, value := range []string{"BRL", "brl", "BR", "BRA", "Brazil", "brazil", "Brasil", "Brazil-Real", " brazil-real "} {
+			resolved, err := currency.Resolve(value)
+
+			assert.NoError(t, err, value)
+			assert.Equal(t, "Brazil-Real", resolved.Key, value)
+		}
+	})
+
+	t.Run("Deve ignorar acentos e espaços extras", func(t *testing.T) {
+		resolved, err := currency.Resolve("  Japão ")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "JPY", resolved.Code)
+	})
+
+	t.Run("Deve resolver o dólar americano", func(t *testing.T) {
+		resolved, err := currency.Resolve("united states of america")
+
+		assert.NoError(t, err)
+		assert.True(t, resolved.IsUSD())
+	})
+
+	t.Run("Deve retornar erro para moeda não suportada", func(t *testing.T) {
+		_, err := currency.Resolve("Atlantis")
+
+		coreErr, ok := err.(*coreErrors.CoreError)
+		assert.True(t, ok, "O erro retornado deve ser do tipo CoreError")
+		assert.Equal(t, "error.currency.not.supported", coreErr.Key)
+	})
+
+	t.Run("Não deve haver apelidos repetidos entre moedas", func(t *testing.T) {
+		seen := make(map[string]string)
+
+		for _, c := range currency.All() {
+			aliases := append([]string{c.Key, c.Country, c.Code}, c.CountryCodes...)
+			aliases = append(aliases, c.Names...)
+
+			for _, alias := range aliases {
+				key := currency.Normalize(alias)
+				if previous, ok := seen[key]; ok {
+					assert.Equal(t, previous, c.Key, "apelido %q repetido", alias)
+				}
+				seen[key] = c.Key
+			}
+		}
+	})
+}
+
+func TestResolveLookup(t *testing.T) {
+	currency.Lookup = func(value string) (found currency.Currency, ok bool, err error) {
+		if value == "Afghanistan" {
+			return currency.Currency{Key: "Afghanistan-Afghani", Country: "Afghanistan", Currency: "Afghani"}, true, nil
+		}
+
+		return
+	}
+	defer func() { currency.Lookup = nil }()
+
+	t.Run("Deve buscar nos dados de cotação a moeda fora do registro", func(t *testing.T) {
+		resolved, err := currency.Resolve(" Afghanistan ")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Afghanistan-Afghani", resolved.Key)
+		assert.Empty(t, resolved.Code)
+	})
+
+	t.Run("Deve preferir o registro à busca", func(t *testing.T) {
+		resolved, err := currency.Resolve("Brasil")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "BRL", resolved.Code)
+	})
+
+	t.Run("Deve exigir código ISO 4217 para moedas de pedidos", func(t *testing.T) {
+		_, err := currency.ResolveCode("Afghanistan")
+
+		coreErr, ok := err.(*coreErrors.CoreError)
+		assert.True(t, ok, "O erro retornado deve ser do tipo CoreError")
+		assert.Equal(t, "error.currency.not.supported", coreErr.Key)
+	})
+
+	t.Run("Deve retornar erro quando a busca não encontrar a moeda", func(t *testing.T) {
+		_, err := currency.Resolve("Atlantis")
+
+		coreErr, ok := err.(*coreErrors.CoreError)
+		assert.True(t, ok, "O erro retornado deve ser do tipo CoreError")
+		assert.Equal(t, "error.currency.not.supported", coreErr.Key)
+	})
+}
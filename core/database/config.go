@@ -10,6 +10,9 @@ import (
 var (
 	DB_QUERIER sqlc.Querier
 	CONN       *sql.DB
+	// DB_TRANSACTION runs fn in a transaction on CONN, replaced in tests to
+	// run it on DB_QUERIER.
+	DB_TRANSACTION = transaction
 )
 
 type Config struct{}
@@ -34,3 +37,27 @@ func (Config) setup() *sql.DB {
 
 	return conn
 }
+
+// transaction runs fn with a querier bound to a new transaction, committed
+// when fn succeeds and rolled back when it returns an error.
+func transaction(fn func(querier sqlc.Querier) error) (err error) {
+	tx, err := CONN.Begin()
+	if err != nil {
+		err = Utils{}.CoreErrorDatabase(err)
+		return
+	}
+
+	err = fn(sqlc.New(tx))
+	if err != nil {
+		tx.Rollback()
+		return
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		err = Utils{}.CoreErrorDatabase(err)
+		return
+	}
+
+	return
+}
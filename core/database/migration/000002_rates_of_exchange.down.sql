@@ -0,0 +1 @@
+DROP TABLE IF EXISTS rates_of_exchange;
@@ -0,0 +1,19 @@
+CREATE TABLE rates_of_exchange (
+    id BIGSERIAL PRIMARY KEY,
+    record_date DATE NOT NULL,
+    country VARCHAR(100) NOT NULL,
+    currency VARCHAR(100) NOT NULL,
+    country_currency_desc VARCHAR(200) NOT NULL,
+    exchange_rate NUMERIC NOT NULL,
+    effective_date DATE NOT NULL,
+    src_line_nbr VARCHAR(10) NOT NULL,
+    record_fiscal_year VARCHAR(4) NOT NULL,
+    record_fiscal_quarter VARCHAR(1) NOT NULL,
+    record_calendar_year VARCHAR(4) NOT NULL,
+    record_calendar_quarter VARCHAR(1) NOT NULL,
+    record_calendar_month VARCHAR(2) NOT NULL,
+    record_calendar_day VARCHAR(2) NOT NULL,
+    UNIQUE (country, currency, effective_date)
+);
+
+CREATE INDEX rates_of_exchange_country_effective_date_idx ON rates_of_exchange (LOWER(country), effective_date);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS rates_of_exchange_country_effective_date_idx;
+
+CREATE INDEX rates_of_exchange_country_currency_desc_effective_date_idx ON rates_of_exchange (LOWER(country_currency_desc), effective_date);
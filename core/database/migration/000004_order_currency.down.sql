@@ -0,0 +1,4 @@
+ALTER TABLE "order"
+    DROP COLUMN IF EXISTS transaction_value_usd,
+    DROP COLUMN IF EXISTS usd_exchange_rate,
+    DROP COLUMN IF EXISTS currency;
@@ -0,0 +1,8 @@
+ALTER TABLE "order"
+    ADD COLUMN currency VARCHAR(3) NOT NULL DEFAULT 'USD',
+    ADD COLUMN usd_exchange_rate FLOAT NOT NULL DEFAULT 1,
+    ADD COLUMN transaction_value_usd FLOAT;
+
+UPDATE "order" SET transaction_value_usd = transaction_value;
+
+ALTER TABLE "order" ALTER COLUMN transaction_value_usd SET NOT NULL;
@@ -0,0 +1,4 @@
+ALTER TABLE "order"
+    ALTER COLUMN transaction_value TYPE FLOAT USING transaction_value::FLOAT,
+    ALTER COLUMN usd_exchange_rate TYPE FLOAT USING usd_exchange_rate::FLOAT,
+    ALTER COLUMN transaction_value_usd TYPE FLOAT USING transaction_value_usd::FLOAT;
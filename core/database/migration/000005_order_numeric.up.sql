@@ -0,0 +1,4 @@
+ALTER TABLE "order"
+    ALTER COLUMN transaction_value TYPE NUMERIC(15, 2) USING ROUND(transaction_value::NUMERIC, 2),
+    ALTER COLUMN usd_exchange_rate TYPE NUMERIC USING usd_exchange_rate::NUMERIC,
+    ALTER COLUMN transaction_value_usd TYPE NUMERIC(15, 2) USING ROUND(transaction_value_usd::NUMERIC, 2);
@@ -0,0 +1,12 @@
+CREATE TABLE conversion (
+    id BIGSERIAL PRIMARY KEY,
+    order_id BIGINT NOT NULL REFERENCES "order" (id),
+    currency VARCHAR(3) NOT NULL,
+    exchange_rate NUMERIC NOT NULL,
+    effective_date DATE NOT NULL,
+    record_date DATE,
+    converted_exchange_rate NUMERIC NOT NULL,
+    converted_value NUMERIC(15, 2) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE (order_id, currency)
+);
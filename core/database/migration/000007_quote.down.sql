@@ -0,0 +1,3 @@
+ALTER TABLE "order" DROP COLUMN IF EXISTS quote_id;
+
+DROP TABLE IF EXISTS quote;
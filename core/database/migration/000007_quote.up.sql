@@ -0,0 +1,12 @@
+CREATE TABLE quote (
+    id BIGSERIAL PRIMARY KEY,
+    currency VARCHAR(3) NOT NULL,
+    amount NUMERIC(15, 2) NOT NULL,
+    exchange_rate NUMERIC NOT NULL,
+    converted_amount NUMERIC(15, 2) NOT NULL,
+    effective_date DATE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMPTZ NOT NULL
+);
+
+ALTER TABLE "order" ADD COLUMN quote_id BIGINT UNIQUE REFERENCES quote (id);
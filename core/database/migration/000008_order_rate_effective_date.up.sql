@@ -0,0 +1 @@
+ALTER TABLE "order" ADD COLUMN usd_rate_effective_date DATE;
@@ -0,0 +1 @@
+ALTER TABLE "order" ADD COLUMN deleted_at TIMESTAMPTZ;
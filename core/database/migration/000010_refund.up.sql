@@ -0,0 +1,10 @@
+CREATE TABLE refund (
+    id BIGSERIAL PRIMARY KEY,
+    order_id BIGINT NOT NULL REFERENCES "order" (id),
+    amount NUMERIC(15, 2) NOT NULL,
+    reason VARCHAR(255) NOT NULL,
+    refund_date TIMESTAMP NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX refund_order_id_idx ON refund (order_id);
@@ -0,0 +1,10 @@
+CREATE TABLE order_item (
+    id BIGSERIAL PRIMARY KEY,
+    order_id BIGINT NOT NULL REFERENCES "order" (id),
+    sku VARCHAR(50) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    unit_price NUMERIC(15, 2) NOT NULL
+);
+
+CREATE INDEX order_item_order_id_idx ON order_item (order_id);
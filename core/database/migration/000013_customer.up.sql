@@ -0,0 +1,10 @@
+CREATE TABLE customer (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    email VARCHAR(255) NOT NULL UNIQUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE "order" ADD COLUMN customer_id BIGINT REFERENCES customer (id);
+
+CREATE INDEX order_customer_id_idx ON "order" (customer_id);
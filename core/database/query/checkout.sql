@@ -3,15 +3,47 @@
 -----------------
 
 -- name: InsertTransaction :one
-INSERT INTO "order" (
-    description,
-    transaction_date,
-    transaction_value
-) VALUES (
-    @description::VARCHAR,
-    @transaction_date::TIMESTAMP,
-    @transaction_value::FLOAT
-) RETURNING id;
+WITH inserted AS (
+    INSERT INTO "order" (
+        description,
+        transaction_date,
+        transaction_value,
+        currency,
+        usd_exchange_rate,
+        transaction_value_usd,
+        quote_id,
+        usd_rate_effective_date,
+        customer_id
+    ) VALUES (
+        @description::VARCHAR,
+        @transaction_date::TIMESTAMP,
+        @transaction_value::NUMERIC,
+        @currency::VARCHAR,
+        @usd_exchange_rate::NUMERIC,
+        @transaction_value_usd::NUMERIC,
+        NULLIF(@quote_id::BIGINT, 0),
+        NULLIF(@usd_rate_effective_date::VARCHAR, '')::DATE,
+        NULLIF(@customer_id::BIGINT, 0)
+    ) RETURNING id
+), items AS (
+    INSERT INTO order_item (
+        order_id,
+        sku,
+        name,
+        quantity,
+        unit_price
+    )
+    SELECT
+        inserted.id,
+        item.sku,
+        item.name,
+        item.quantity,
+        item.unit_price
+    FROM
+        inserted,
+        UNNEST(@item_skus::VARCHAR[], @item_names::VARCHAR[], @item_quantities::INTEGER[], @item_unit_prices::NUMERIC[]) AS item (sku, name, quantity, unit_price)
+)
+SELECT id FROM inserted;
 
 -----------------
 ---- INSERTS ----
@@ -26,12 +58,22 @@ SELECT
     id,
     description,
     transaction_date::TIMESTAMP AS transaction_date,
-    transaction_value
+    transaction_value,
+    currency,
+    usd_exchange_rate,
+    transaction_value_usd,
+    COALESCE(TO_CHAR(usd_rate_effective_date, 'YYYY-MM-DD'), '')::VARCHAR AS usd_rate_effective_date,
+    COALESCE(customer_id, 0)::BIGINT AS customer_id,
+    status,
+    (deleted_at IS NOT NULL)::BOOLEAN AS deleted
 FROM
     "order"
 WHERE
 	(CASE WHEN @transaction_date::VARCHAR <> '' THEN transaction_date::DATE >= @transaction_date::DATE ELSE TRUE END)
     AND (CASE WHEN @transaction_date::VARCHAR <> '' THEN transaction_date::DATE <= @transaction_date::DATE ELSE TRUE END)
+    AND (@include_deleted::BOOLEAN OR deleted_at IS NULL)
+    AND (@status::VARCHAR = '' OR status = @status::VARCHAR)
+    AND (@customer_id::BIGINT = 0 OR customer_id = @customer_id::BIGINT)
 LIMIT $1::BIGINT
 OFFSET $2::BIGINT;
 
@@ -42,18 +84,81 @@ FROM
     "order"
 WHERE
 	(CASE WHEN @transaction_date::VARCHAR <> '' THEN transaction_date::DATE >= @transaction_date::DATE ELSE TRUE END)
-    AND (CASE WHEN @transaction_date::VARCHAR <> '' THEN transaction_date::DATE <= @transaction_date::DATE ELSE TRUE END);
+    AND (CASE WHEN @transaction_date::VARCHAR <> '' THEN transaction_date::DATE <= @transaction_date::DATE ELSE TRUE END)
+    AND (@include_deleted::BOOLEAN OR deleted_at IS NULL)
+    AND (@status::VARCHAR = '' OR status = @status::VARCHAR)
+    AND (@customer_id::BIGINT = 0 OR customer_id = @customer_id::BIGINT);
 
 -- name: SelectTransactionByID :one
 SELECT 
     id,
 	description,
     transaction_date::TIMESTAMP AS transaction_date,
-    transaction_value
+    transaction_value,
+    currency,
+    usd_exchange_rate,
+    transaction_value_usd,
+    COALESCE(TO_CHAR(usd_rate_effective_date, 'YYYY-MM-DD'), '')::VARCHAR AS usd_rate_effective_date,
+    COALESCE(quote_id, 0)::BIGINT AS quote_id,
+    COALESCE(customer_id, 0)::BIGINT AS customer_id,
+    status,
+    (deleted_at IS NOT NULL)::BOOLEAN AS deleted
 FROM 
 	"order"
 WHERE
-	id = @id::BIGINT;
+	id = @id::BIGINT
+    AND (@include_deleted::BOOLEAN OR deleted_at IS NULL);
+-- name: SelectTransactionItems :many
+SELECT
+    id,
+    order_id,
+    sku,
+    name,
+    quantity,
+    unit_price
+FROM
+    order_item
+WHERE
+    order_id = @order_id::BIGINT
+ORDER BY
+    id;
+
 -----------------
 ---- SELECTS ----
------------------
\ No newline at end of file
+-----------------
+
+-----------------
+---- UPDATES ----
+-----------------
+
+-- name: UpdateTransaction :one
+UPDATE "order" SET
+    description = @description::VARCHAR,
+    transaction_date = @transaction_date::TIMESTAMP,
+    transaction_value = @transaction_value::NUMERIC,
+    usd_exchange_rate = @usd_exchange_rate::NUMERIC,
+    transaction_value_usd = @transaction_value_usd::NUMERIC,
+    usd_rate_effective_date = NULLIF(@usd_rate_effective_date::VARCHAR, '')::DATE
+WHERE
+    id = @id::BIGINT
+RETURNING id;
+
+-- name: DeleteTransaction :one
+UPDATE "order" SET
+    deleted_at = NOW()
+WHERE
+    id = @id::BIGINT
+    AND deleted_at IS NULL
+RETURNING id;
+
+-- name: RestoreTransaction :one
+UPDATE "order" SET
+    deleted_at = NULL
+WHERE
+    id = @id::BIGINT
+    AND deleted_at IS NOT NULL
+RETURNING id;
+
+-----------------
+---- UPDATES ----
+-----------------
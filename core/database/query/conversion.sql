@@ -0,0 +1,72 @@
+-----------------
+---- UPSERTS ----
+-----------------
+
+-- name: UpsertConversion :exec
+INSERT INTO conversion (
+    order_id,
+    currency,
+    exchange_rate,
+    effective_date,
+    record_date,
+    converted_exchange_rate,
+    converted_value
+) VALUES (
+    @order_id::BIGINT,
+    @currency::VARCHAR,
+    @exchange_rate::NUMERIC,
+    @effective_date::DATE,
+    NULLIF(@record_date::VARCHAR, '')::DATE,
+    @converted_exchange_rate::NUMERIC,
+    @converted_value::NUMERIC
+)
+ON CONFLICT (order_id, currency) DO UPDATE SET
+    exchange_rate = EXCLUDED.exchange_rate,
+    effective_date = EXCLUDED.effective_date,
+    record_date = EXCLUDED.record_date,
+    converted_exchange_rate = EXCLUDED.converted_exchange_rate,
+    converted_value = EXCLUDED.converted_value,
+    created_at = NOW()
+WHERE
+    @refresh::BOOLEAN;
+
+-----------------
+---- UPSERTS ----
+-----------------
+
+-----------------
+---- SELECTS ----
+-----------------
+
+-- name: SelectConversions :many
+SELECT
+    order_id,
+    currency,
+    exchange_rate,
+    TO_CHAR(effective_date, 'YYYY-MM-DD')::VARCHAR AS effective_date,
+    COALESCE(TO_CHAR(record_date, 'YYYY-MM-DD'), '')::VARCHAR AS record_date,
+    converted_exchange_rate,
+    converted_value
+FROM
+    conversion
+WHERE
+    order_id = ANY(@order_ids::BIGINT[])
+    AND currency = ANY(@currencies::VARCHAR[]);
+
+-----------------
+---- SELECTS ----
+-----------------
+
+-----------------
+---- DELETES ----
+-----------------
+
+-- name: DeleteConversions :exec
+DELETE FROM
+    conversion
+WHERE
+    order_id = @order_id::BIGINT;
+
+-----------------
+---- DELETES ----
+-----------------
@@ -0,0 +1,105 @@
+-----------------
+---- INSERTS ----
+-----------------
+
+-- name: InsertCustomer :one
+INSERT INTO customer (
+    name,
+    email
+) VALUES (
+    @name::VARCHAR,
+    @email::VARCHAR
+) RETURNING id;
+
+-----------------
+---- INSERTS ----
+-----------------
+
+-----------------
+---- SELECTS ----
+-----------------
+
+-- name: SelectCustomers :many
+SELECT
+    id,
+    name,
+    email,
+    created_at
+FROM
+    customer
+ORDER BY
+    id
+LIMIT $1::BIGINT
+OFFSET $2::BIGINT;
+
+-- name: SelectCustomersTotal :one
+SELECT
+    count(id) AS total
+FROM
+    customer;
+
+-- name: SelectCustomerByID :one
+SELECT
+    id,
+    name,
+    email,
+    created_at
+FROM
+    customer
+WHERE
+    id = @id::BIGINT;
+
+-- name: SelectCustomerTransactions :many
+SELECT
+    id,
+    transaction_date::TIMESTAMP AS transaction_date,
+    transaction_value,
+    currency,
+    usd_exchange_rate,
+    transaction_value_usd,
+    COALESCE(TO_CHAR(usd_rate_effective_date, 'YYYY-MM-DD'), '')::VARCHAR AS usd_rate_effective_date,
+    COALESCE((SELECT SUM(refund.amount) FROM refund WHERE refund.order_id = "order".id), 0)::NUMERIC AS refunded
+FROM
+    "order"
+WHERE
+    customer_id = @customer_id::BIGINT
+    AND id > @after_id::BIGINT
+    AND deleted_at IS NULL
+    AND status <> 'cancelled'
+ORDER BY
+    id
+LIMIT @page_size::BIGINT;
+
+-----------------
+---- SELECTS ----
+-----------------
+
+-----------------
+---- UPDATES ----
+-----------------
+
+-- name: UpdateCustomer :one
+UPDATE customer SET
+    name = @name::VARCHAR,
+    email = @email::VARCHAR
+WHERE
+    id = @id::BIGINT
+RETURNING id;
+
+-----------------
+---- UPDATES ----
+-----------------
+
+-----------------
+---- DELETES ----
+-----------------
+
+-- name: DeleteCustomer :one
+DELETE FROM customer
+WHERE
+    id = @id::BIGINT
+RETURNING id;
+
+-----------------
+---- DELETES ----
+-----------------
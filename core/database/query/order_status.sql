@@ -0,0 +1,56 @@
+-----------------
+---- UPDATES ----
+-----------------
+
+-- name: TransitionTransactionStatus :one
+WITH updated AS (
+    UPDATE "order" SET
+        status = @to_status::VARCHAR
+    WHERE
+        id = @order_id::BIGINT
+        AND status = @from_status::VARCHAR
+        AND deleted_at IS NULL
+    RETURNING id
+)
+INSERT INTO order_status_history (
+    order_id,
+    from_status,
+    to_status,
+    reason
+)
+SELECT
+    id,
+    @from_status::VARCHAR,
+    @to_status::VARCHAR,
+    @reason::VARCHAR
+FROM
+    updated
+RETURNING id, created_at;
+
+-----------------
+---- UPDATES ----
+-----------------
+
+-----------------
+---- SELECTS ----
+-----------------
+
+-- name: SelectTransactionStatusHistory :many
+SELECT
+    id,
+    order_id,
+    from_status,
+    to_status,
+    reason,
+    created_at
+FROM
+    order_status_history
+WHERE
+    order_id = @order_id::BIGINT
+ORDER BY
+    created_at,
+    id;
+
+-----------------
+---- SELECTS ----
+-----------------
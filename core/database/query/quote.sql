@@ -0,0 +1,48 @@
+-----------------
+---- INSERTS ----
+-----------------
+
+-- name: InsertQuote :one
+INSERT INTO quote (
+    currency,
+    amount,
+    exchange_rate,
+    converted_amount,
+    effective_date,
+    expires_at
+) VALUES (
+    @currency::VARCHAR,
+    @amount::NUMERIC,
+    @exchange_rate::NUMERIC,
+    @converted_amount::NUMERIC,
+    NULLIF(@effective_date::VARCHAR, '')::DATE,
+    NOW() + @ttl_seconds::BIGINT * INTERVAL '1 second'
+) RETURNING id, expires_at;
+
+-----------------
+---- INSERTS ----
+-----------------
+
+-----------------
+---- SELECTS ----
+-----------------
+
+-- name: SelectQuoteByID :one
+SELECT
+    id,
+    currency,
+    amount,
+    exchange_rate,
+    converted_amount,
+    COALESCE(TO_CHAR(effective_date, 'YYYY-MM-DD'), '')::VARCHAR AS effective_date,
+    expires_at,
+    (expires_at <= NOW())::BOOLEAN AS expired,
+    EXISTS (SELECT 1 FROM "order" WHERE "order".quote_id = quote.id)::BOOLEAN AS used
+FROM
+    quote
+WHERE
+    id = @id::BIGINT;
+
+-----------------
+---- SELECTS ----
+-----------------
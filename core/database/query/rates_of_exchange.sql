@@ -0,0 +1,153 @@
+-----------------
+---- UPSERTS ----
+-----------------
+
+-- name: UpsertRateOfExchange :one
+INSERT INTO rates_of_exchange (
+    record_date,
+    country,
+    currency,
+    country_currency_desc,
+    exchange_rate,
+    effective_date,
+    src_line_nbr,
+    record_fiscal_year,
+    record_fiscal_quarter,
+    record_calendar_year,
+    record_calendar_quarter,
+    record_calendar_month,
+    record_calendar_day
+) VALUES (
+    @record_date::DATE,
+    @country::VARCHAR,
+    @currency::VARCHAR,
+    @country_currency_desc::VARCHAR,
+    @exchange_rate::NUMERIC,
+    @effective_date::DATE,
+    @src_line_nbr::VARCHAR,
+    @record_fiscal_year::VARCHAR,
+    @record_fiscal_quarter::VARCHAR,
+    @record_calendar_year::VARCHAR,
+    @record_calendar_quarter::VARCHAR,
+    @record_calendar_month::VARCHAR,
+    @record_calendar_day::VARCHAR
+)
+ON CONFLICT (country, currency, effective_date) DO UPDATE SET
+    record_date = EXCLUDED.record_date,
+    country_currency_desc = EXCLUDED.country_currency_desc,
+    exchange_rate = EXCLUDED.exchange_rate,
+    src_line_nbr = EXCLUDED.src_line_nbr,
+    record_fiscal_year = EXCLUDED.record_fiscal_year,
+    record_fiscal_quarter = EXCLUDED.record_fiscal_quarter,
+    record_calendar_year = EXCLUDED.record_calendar_year,
+    record_calendar_quarter = EXCLUDED.record_calendar_quarter,
+    record_calendar_month = EXCLUDED.record_calendar_month,
+    record_calendar_day = EXCLUDED.record_calendar_day
+WHERE
+    rates_of_exchange.exchange_rate <> EXCLUDED.exchange_rate
+    OR rates_of_exchange.record_date <> EXCLUDED.record_date
+    OR rates_of_exchange.country_currency_desc <> EXCLUDED.country_currency_desc
+RETURNING (xmax = 0)::BOOLEAN AS inserted;
+
+-----------------
+---- UPSERTS ----
+-----------------
+
+-----------------
+---- SELECTS ----
+-----------------
+
+-- name: SelectRatesOfExchange :many
+SELECT
+    TO_CHAR(record_date, 'YYYY-MM-DD')::VARCHAR AS record_date,
+    country,
+    currency,
+    country_currency_desc,
+    exchange_rate::VARCHAR AS exchange_rate,
+    TO_CHAR(effective_date, 'YYYY-MM-DD')::VARCHAR AS effective_date,
+    src_line_nbr,
+    record_fiscal_year,
+    record_fiscal_quarter,
+    record_calendar_year,
+    record_calendar_quarter,
+    record_calendar_month,
+    record_calendar_day
+FROM
+    rates_of_exchange
+WHERE
+    LOWER(country_currency_desc) = LOWER(@country_currency_desc::VARCHAR)
+    AND effective_date >= @effective_date_from::DATE
+    AND effective_date <= @effective_date_to::DATE
+ORDER BY
+    effective_date DESC;
+
+-- name: SelectRatesOfExchangeHistory :many
+SELECT
+    TO_CHAR(record_date, 'YYYY-MM-DD')::VARCHAR AS record_date,
+    country,
+    currency,
+    country_currency_desc,
+    exchange_rate::VARCHAR AS exchange_rate,
+    TO_CHAR(effective_date, 'YYYY-MM-DD')::VARCHAR AS effective_date
+FROM
+    rates_of_exchange
+WHERE
+    LOWER(country_currency_desc) = LOWER(@country_currency_desc::VARCHAR)
+    AND (CASE WHEN @effective_date_from::VARCHAR <> '' THEN effective_date >= @effective_date_from::DATE ELSE TRUE END)
+    AND (CASE WHEN @effective_date_to::VARCHAR <> '' THEN effective_date <= @effective_date_to::DATE ELSE TRUE END)
+ORDER BY
+    effective_date
+LIMIT @page_size::BIGINT
+OFFSET @page_offset::BIGINT;
+
+-- name: SelectRatesOfExchangeHistoryTotal :one
+SELECT
+    count(*) AS total
+FROM
+    rates_of_exchange
+WHERE
+    LOWER(country_currency_desc) = LOWER(@country_currency_desc::VARCHAR)
+    AND (CASE WHEN @effective_date_from::VARCHAR <> '' THEN effective_date >= @effective_date_from::DATE ELSE TRUE END)
+    AND (CASE WHEN @effective_date_to::VARCHAR <> '' THEN effective_date <= @effective_date_to::DATE ELSE TRUE END);
+
+-- name: SelectCurrencies :many
+SELECT DISTINCT ON (country_currency_desc)
+    country,
+    currency,
+    country_currency_desc,
+    TO_CHAR(effective_date, 'YYYY-MM-DD')::VARCHAR AS effective_date,
+    exchange_rate::VARCHAR AS exchange_rate
+FROM
+    rates_of_exchange
+WHERE
+    (CASE WHEN @name::VARCHAR <> '' THEN
+        country ILIKE @name::VARCHAR || '%'
+        OR currency ILIKE @name::VARCHAR || '%'
+        OR country_currency_desc ILIKE @name::VARCHAR || '%'
+    ELSE TRUE END)
+ORDER BY
+    country_currency_desc,
+    effective_date DESC;
+
+-- name: SelectCurrencyByName :one
+SELECT
+    country,
+    currency,
+    country_currency_desc
+FROM
+    rates_of_exchange
+WHERE
+    LOWER(country_currency_desc) = LOWER(@name::VARCHAR)
+    OR LOWER(country) = LOWER(@name::VARCHAR)
+ORDER BY
+    effective_date DESC
+LIMIT 1;
+
+-- name: SelectRatesOfExchangeLastRecordDate :one
+SELECT
+    COALESCE(TO_CHAR(MAX(record_date), 'YYYY-MM-DD'), '')::VARCHAR AS record_date
+FROM
+    rates_of_exchange;
+-----------------
+---- SELECTS ----
+-----------------
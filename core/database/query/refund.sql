@@ -0,0 +1,62 @@
+-----------------
+---- INSERTS ----
+-----------------
+
+-- name: InsertRefund :one
+INSERT INTO refund (
+    order_id,
+    amount,
+    reason,
+    refund_date
+) VALUES (
+    @order_id::BIGINT,
+    @amount::NUMERIC,
+    @reason::VARCHAR,
+    @refund_date::TIMESTAMP
+) RETURNING id;
+
+-----------------
+---- INSERTS ----
+-----------------
+
+-----------------
+---- SELECTS ----
+-----------------
+
+-- name: SelectRefunds :many
+SELECT
+    id,
+    order_id,
+    amount,
+    reason,
+    refund_date
+FROM
+    refund
+WHERE
+    order_id = @order_id::BIGINT
+ORDER BY
+    refund_date,
+    id;
+
+-- name: SelectRefundsTotal :one
+SELECT
+    COALESCE(SUM(amount), 0)::NUMERIC AS total
+FROM
+    refund
+WHERE
+    order_id = @order_id::BIGINT;
+
+-- name: SelectTransactionForUpdate :one
+SELECT
+    transaction_value,
+    status
+FROM
+    "order"
+WHERE
+    id = @id::BIGINT
+    AND deleted_at IS NULL
+FOR UPDATE;
+
+-----------------
+---- SELECTS ----
+-----------------
@@ -8,68 +8,233 @@ package sqlc
 import (
 	"context"
 	"time"
+
+	"github.com/lib/pq"
+	"github.com/shopspring/decimal"
 )
 
+const deleteTransaction = `-- name: DeleteTransaction :one
+UPDATE "order" SET
+    deleted_at = NOW()
+WHERE
+    id = $1::BIGINT
+    AND deleted_at IS NULL
+RETURNING id
+`
+
+func (q *Queries) DeleteTransaction(ctx context.Context, id int64) (int64, error) {
+	row := q.queryRow(ctx, q.deleteTransactionStmt, deleteTransaction, id)
+	err := row.Scan(&id)
+	return id, err
+}
+
 const insertTransaction = `-- name: InsertTransaction :one
 
-INSERT INTO "order" (
-    description,
-    transaction_date,
-    transaction_value
-) VALUES (
-    $1::VARCHAR,
-    $2::TIMESTAMP,
-    $3::FLOAT
-) RETURNING id
+WITH inserted AS (
+    INSERT INTO "order" (
+        description,
+        transaction_date,
+        transaction_value,
+        currency,
+        usd_exchange_rate,
+        transaction_value_usd,
+        quote_id,
+        usd_rate_effective_date,
+        customer_id
+    ) VALUES (
+        $1::VARCHAR,
+        $2::TIMESTAMP,
+        $3::NUMERIC,
+        $4::VARCHAR,
+        $5::NUMERIC,
+        $6::NUMERIC,
+        NULLIF($7::BIGINT, 0),
+        NULLIF($8::VARCHAR, '')::DATE,
+        NULLIF($9::BIGINT, 0)
+    ) RETURNING id
+), items AS (
+    INSERT INTO order_item (
+        order_id,
+        sku,
+        name,
+        quantity,
+        unit_price
+    )
+    SELECT
+        inserted.id,
+        item.sku,
+        item.name,
+        item.quantity,
+        item.unit_price
+    FROM
+        inserted,
+        UNNEST($10::VARCHAR[], $11::VARCHAR[], $12::INTEGER[], $13::NUMERIC[]) AS item (sku, name, quantity, unit_price)
+)
+SELECT id FROM inserted
 `
 
 type InsertTransactionParams struct {
-	Description      string
-	TransactionDate  time.Time
-	TransactionValue float64
+	Description          string
+	TransactionDate      time.Time
+	TransactionValue     decimal.Decimal
+	Currency             string
+	UsdExchangeRate      decimal.Decimal
+	TransactionValueUsd  decimal.Decimal
+	QuoteID              int64
+	UsdRateEffectiveDate string
+	CustomerID           int64
+	ItemSkus             []string
+	ItemNames            []string
+	ItemQuantities       []int32
+	ItemUnitPrices       []decimal.Decimal
 }
 
 // ---------------
 // -- INSERTS ----
 // ---------------
 func (q *Queries) InsertTransaction(ctx context.Context, arg InsertTransactionParams) (int64, error) {
-	row := q.queryRow(ctx, q.insertTransactionStmt, insertTransaction, arg.Description, arg.TransactionDate, arg.TransactionValue)
+	row := q.queryRow(ctx, q.insertTransactionStmt, insertTransaction,
+		arg.Description,
+		arg.TransactionDate,
+		arg.TransactionValue,
+		arg.Currency,
+		arg.UsdExchangeRate,
+		arg.TransactionValueUsd,
+		arg.QuoteID,
+		arg.UsdRateEffectiveDate,
+		arg.CustomerID,
+		pq.Array(arg.ItemSkus),
+		pq.Array(arg.ItemNames),
+		pq.Array(arg.ItemQuantities),
+		pq.Array(arg.ItemUnitPrices),
+	)
 	var id int64
 	err := row.Scan(&id)
 	return id, err
 }
 
+const restoreTransaction = `-- name: RestoreTransaction :one
+UPDATE "order" SET
+    deleted_at = NULL
+WHERE
+    id = $1::BIGINT
+    AND deleted_at IS NOT NULL
+RETURNING id
+`
+
+func (q *Queries) RestoreTransaction(ctx context.Context, id int64) (int64, error) {
+	row := q.queryRow(ctx, q.restoreTransactionStmt, restoreTransaction, id)
+	err := row.Scan(&id)
+	return id, err
+}
+
 const selectTransactionByID = `-- name: SelectTransactionByID :one
 SELECT 
     id,
 	description,
     transaction_date::TIMESTAMP AS transaction_date,
-    transaction_value
+    transaction_value,
+    currency,
+    usd_exchange_rate,
+    transaction_value_usd,
+    COALESCE(TO_CHAR(usd_rate_effective_date, 'YYYY-MM-DD'), '')::VARCHAR AS usd_rate_effective_date,
+    COALESCE(quote_id, 0)::BIGINT AS quote_id,
+    COALESCE(customer_id, 0)::BIGINT AS customer_id,
+    status,
+    (deleted_at IS NOT NULL)::BOOLEAN AS deleted
 FROM 
 	"order"
 WHERE
 	id = $1::BIGINT
+    AND ($2::BOOLEAN OR deleted_at IS NULL)
 `
 
+type SelectTransactionByIDParams struct {
+	ID             int64
+	IncludeDeleted bool
+}
+
 type SelectTransactionByIDRow struct {
-	ID               int64
-	Description      string
-	TransactionDate  time.Time
-	TransactionValue float64
+	ID                   int64
+	Description          string
+	TransactionDate      time.Time
+	TransactionValue     decimal.Decimal
+	Currency             string
+	UsdExchangeRate      decimal.Decimal
+	TransactionValueUsd  decimal.Decimal
+	UsdRateEffectiveDate string
+	QuoteID              int64
+	CustomerID           int64
+	Status               string
+	Deleted              bool
 }
 
-func (q *Queries) SelectTransactionByID(ctx context.Context, id int64) (SelectTransactionByIDRow, error) {
-	row := q.queryRow(ctx, q.selectTransactionByIDStmt, selectTransactionByID, id)
+func (q *Queries) SelectTransactionByID(ctx context.Context, arg SelectTransactionByIDParams) (SelectTransactionByIDRow, error) {
+	row := q.queryRow(ctx, q.selectTransactionByIDStmt, selectTransactionByID, arg.ID, arg.IncludeDeleted)
 	var i SelectTransactionByIDRow
 	err := row.Scan(
 		&i.ID,
 		&i.Description,
 		&i.TransactionDate,
 		&i.TransactionValue,
+		&i.Currency,
+		&i.UsdExchangeRate,
+		&i.TransactionValueUsd,
+		&i.UsdRateEffectiveDate,
+		&i.QuoteID,
+		&i.CustomerID,
+		&i.Status,
+		&i.Deleted,
 	)
 	return i, err
 }
 
+const selectTransactionItems = `-- name: SelectTransactionItems :many
+SELECT
+    id,
+    order_id,
+    sku,
+    name,
+    quantity,
+    unit_price
+FROM
+    order_item
+WHERE
+    order_id = $1::BIGINT
+ORDER BY
+    id
+`
+
+func (q *Queries) SelectTransactionItems(ctx context.Context, orderID int64) ([]OrderItem, error) {
+	rows, err := q.query(ctx, q.selectTransactionItemsStmt, selectTransactionItems, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []OrderItem{}
+	for rows.Next() {
+		var i OrderItem
+		if err := rows.Scan(
+			&i.ID,
+			&i.OrderID,
+			&i.Sku,
+			&i.Name,
+			&i.Quantity,
+			&i.UnitPrice,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const selectTransactions = `-- name: SelectTransactions :many
 
 
@@ -77,12 +242,22 @@ SELECT
     id,
     description,
     transaction_date::TIMESTAMP AS transaction_date,
-    transaction_value
+    transaction_value,
+    currency,
+    usd_exchange_rate,
+    transaction_value_usd,
+    COALESCE(TO_CHAR(usd_rate_effective_date, 'YYYY-MM-DD'), '')::VARCHAR AS usd_rate_effective_date,
+    COALESCE(customer_id, 0)::BIGINT AS customer_id,
+    status,
+    (deleted_at IS NOT NULL)::BOOLEAN AS deleted
 FROM
     "order"
 WHERE
 	(CASE WHEN $3::VARCHAR <> '' THEN transaction_date::DATE >= $3::DATE ELSE TRUE END)
     AND (CASE WHEN $3::VARCHAR <> '' THEN transaction_date::DATE <= $3::DATE ELSE TRUE END)
+    AND ($4::BOOLEAN OR deleted_at IS NULL)
+    AND ($5::VARCHAR = '' OR status = $5::VARCHAR)
+    AND ($6::BIGINT = 0 OR customer_id = $6::BIGINT)
 LIMIT $1::BIGINT
 OFFSET $2::BIGINT
 `
@@ -91,13 +266,23 @@ type SelectTransactionsParams struct {
 	Column1         int64
 	Column2         int64
 	TransactionDate string
+	IncludeDeleted  bool
+	Status          string
+	CustomerID      int64
 }
 
 type SelectTransactionsRow struct {
-	ID               int64
-	Description      string
-	TransactionDate  time.Time
-	TransactionValue float64
+	ID                   int64
+	Description          string
+	TransactionDate      time.Time
+	TransactionValue     decimal.Decimal
+	Currency             string
+	UsdExchangeRate      decimal.Decimal
+	TransactionValueUsd  decimal.Decimal
+	UsdRateEffectiveDate string
+	CustomerID           int64
+	Status               string
+	Deleted              bool
 }
 
 // ---------------
@@ -107,7 +292,14 @@ type SelectTransactionsRow struct {
 // -- SELECTS ----
 // ---------------
 func (q *Queries) SelectTransactions(ctx context.Context, arg SelectTransactionsParams) ([]SelectTransactionsRow, error) {
-	rows, err := q.query(ctx, q.selectTransactionsStmt, selectTransactions, arg.Column1, arg.Column2, arg.TransactionDate)
+	rows, err := q.query(ctx, q.selectTransactionsStmt, selectTransactions,
+		arg.Column1,
+		arg.Column2,
+		arg.TransactionDate,
+		arg.IncludeDeleted,
+		arg.Status,
+		arg.CustomerID,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -120,6 +312,13 @@ func (q *Queries) SelectTransactions(ctx context.Context, arg SelectTransactions
 			&i.Description,
 			&i.TransactionDate,
 			&i.TransactionValue,
+			&i.Currency,
+			&i.UsdExchangeRate,
+			&i.TransactionValueUsd,
+			&i.UsdRateEffectiveDate,
+			&i.CustomerID,
+			&i.Status,
+			&i.Deleted,
 		); err != nil {
 			return nil, err
 		}
@@ -142,11 +341,71 @@ FROM
 WHERE
 	(CASE WHEN $1::VARCHAR <> '' THEN transaction_date::DATE >= $1::DATE ELSE TRUE END)
     AND (CASE WHEN $1::VARCHAR <> '' THEN transaction_date::DATE <= $1::DATE ELSE TRUE END)
+    AND ($2::BOOLEAN OR deleted_at IS NULL)
+    AND ($3::VARCHAR = '' OR status = $3::VARCHAR)
+    AND ($4::BIGINT = 0 OR customer_id = $4::BIGINT)
 `
 
-func (q *Queries) SelectTransactionsTotal(ctx context.Context, transactionDate string) (int64, error) {
-	row := q.queryRow(ctx, q.selectTransactionsTotalStmt, selectTransactionsTotal, transactionDate)
+type SelectTransactionsTotalParams struct {
+	TransactionDate string
+	IncludeDeleted  bool
+	Status          string
+	CustomerID      int64
+}
+
+func (q *Queries) SelectTransactionsTotal(ctx context.Context, arg SelectTransactionsTotalParams) (int64, error) {
+	row := q.queryRow(ctx, q.selectTransactionsTotalStmt, selectTransactionsTotal,
+		arg.TransactionDate,
+		arg.IncludeDeleted,
+		arg.Status,
+		arg.CustomerID,
+	)
 	var total int64
 	err := row.Scan(&total)
 	return total, err
 }
+
+const updateTransaction = `-- name: UpdateTransaction :one
+
+UPDATE "order" SET
+    description = $1::VARCHAR,
+    transaction_date = $2::TIMESTAMP,
+    transaction_value = $3::NUMERIC,
+    usd_exchange_rate = $4::NUMERIC,
+    transaction_value_usd = $5::NUMERIC,
+    usd_rate_effective_date = NULLIF($6::VARCHAR, '')::DATE
+WHERE
+    id = $7::BIGINT
+RETURNING id
+`
+
+type UpdateTransactionParams struct {
+	Description          string
+	TransactionDate      time.Time
+	TransactionValue     decimal.Decimal
+	UsdExchangeRate      decimal.Decimal
+	TransactionValueUsd  decimal.Decimal
+	UsdRateEffectiveDate string
+	ID                   int64
+}
+
+// ---------------
+// -- SELECTS ----
+// ---------------
+// ---------------
+// -- UPDATES ----
+// ---------------
+func (q *Queries) UpdateTransaction(ctx context.Context, arg UpdateTransactionParams) (int64, error) {
+	row := q.queryRow(ctx, q.updateTransactionStmt, updateTransaction,
+		arg.Description,
+		arg.TransactionDate,
+		arg.TransactionValue,
+		arg.UsdExchangeRate,
+		arg.TransactionValueUsd,
+		arg.UsdRateEffectiveDate,
+		arg.ID,
+	)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
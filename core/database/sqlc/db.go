@@ -24,33 +24,240 @@ func New(db DBTX) *Queries {
 func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	q := Queries{db: db}
 	var err error
+	if q.deleteConversionsStmt, err = db.PrepareContext(ctx, deleteConversions); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteConversions: %w", err)
+	}
+	if q.deleteCustomerStmt, err = db.PrepareContext(ctx, deleteCustomer); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteCustomer: %w", err)
+	}
+	if q.deleteTransactionStmt, err = db.PrepareContext(ctx, deleteTransaction); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteTransaction: %w", err)
+	}
+	if q.insertCustomerStmt, err = db.PrepareContext(ctx, insertCustomer); err != nil {
+		return nil, fmt.Errorf("error preparing query InsertCustomer: %w", err)
+	}
+	if q.insertQuoteStmt, err = db.PrepareContext(ctx, insertQuote); err != nil {
+		return nil, fmt.Errorf("error preparing query InsertQuote: %w", err)
+	}
+	if q.insertRefundStmt, err = db.PrepareContext(ctx, insertRefund); err != nil {
+		return nil, fmt.Errorf("error preparing query InsertRefund: %w", err)
+	}
 	if q.insertTransactionStmt, err = db.PrepareContext(ctx, insertTransaction); err != nil {
 		return nil, fmt.Errorf("error preparing query InsertTransaction: %w", err)
 	}
+	if q.restoreTransactionStmt, err = db.PrepareContext(ctx, restoreTransaction); err != nil {
+		return nil, fmt.Errorf("error preparing query RestoreTransaction: %w", err)
+	}
+	if q.selectConversionsStmt, err = db.PrepareContext(ctx, selectConversions); err != nil {
+		return nil, fmt.Errorf("error preparing query SelectConversions: %w", err)
+	}
+	if q.selectCurrenciesStmt, err = db.PrepareContext(ctx, selectCurrencies); err != nil {
+		return nil, fmt.Errorf("error preparing query SelectCurrencies: %w", err)
+	}
+	if q.selectCurrencyByNameStmt, err = db.PrepareContext(ctx, selectCurrencyByName); err != nil {
+		return nil, fmt.Errorf("error preparing query SelectCurrencyByName: %w", err)
+	}
+	if q.selectCustomerByIDStmt, err = db.PrepareContext(ctx, selectCustomerByID); err != nil {
+		return nil, fmt.Errorf("error preparing query SelectCustomerByID: %w", err)
+	}
+	if q.selectCustomerTransactionsStmt, err = db.PrepareContext(ctx, selectCustomerTransactions); err != nil {
+		return nil, fmt.Errorf("error preparing query SelectCustomerTransactions: %w", err)
+	}
+	if q.selectCustomersStmt, err = db.PrepareContext(ctx, selectCustomers); err != nil {
+		return nil, fmt.Errorf("error preparing query SelectCustomers: %w", err)
+	}
+	if q.selectCustomersTotalStmt, err = db.PrepareContext(ctx, selectCustomersTotal); err != nil {
+		return nil, fmt.Errorf("error preparing query SelectCustomersTotal: %w", err)
+	}
+	if q.selectQuoteByIDStmt, err = db.PrepareContext(ctx, selectQuoteByID); err != nil {
+		return nil, fmt.Errorf("error preparing query SelectQuoteByID: %w", err)
+	}
+	if q.selectRatesOfExchangeStmt, err = db.PrepareContext(ctx, selectRatesOfExchange); err != nil {
+		return nil, fmt.Errorf("error preparing query SelectRatesOfExchange: %w", err)
+	}
+	if q.selectRatesOfExchangeHistoryStmt, err = db.PrepareContext(ctx, selectRatesOfExchangeHistory); err != nil {
+		return nil, fmt.Errorf("error preparing query SelectRatesOfExchangeHistory: %w", err)
+	}
+	if q.selectRatesOfExchangeHistoryTotalStmt, err = db.PrepareContext(ctx, selectRatesOfExchangeHistoryTotal); err != nil {
+		return nil, fmt.Errorf("error preparing query SelectRatesOfExchangeHistoryTotal: %w", err)
+	}
+	if q.selectRatesOfExchangeLastRecordDateStmt, err = db.PrepareContext(ctx, selectRatesOfExchangeLastRecordDate); err != nil {
+		return nil, fmt.Errorf("error preparing query SelectRatesOfExchangeLastRecordDate: %w", err)
+	}
+	if q.selectRefundsStmt, err = db.PrepareContext(ctx, selectRefunds); err != nil {
+		return nil, fmt.Errorf("error preparing query SelectRefunds: %w", err)
+	}
+	if q.selectRefundsTotalStmt, err = db.PrepareContext(ctx, selectRefundsTotal); err != nil {
+		return nil, fmt.Errorf("error preparing query SelectRefundsTotal: %w", err)
+	}
 	if q.selectTransactionByIDStmt, err = db.PrepareContext(ctx, selectTransactionByID); err != nil {
 		return nil, fmt.Errorf("error preparing query SelectTransactionByID: %w", err)
 	}
+	if q.selectTransactionForUpdateStmt, err = db.PrepareContext(ctx, selectTransactionForUpdate); err != nil {
+		return nil, fmt.Errorf("error preparing query SelectTransactionForUpdate: %w", err)
+	}
+	if q.selectTransactionItemsStmt, err = db.PrepareContext(ctx, selectTransactionItems); err != nil {
+		return nil, fmt.Errorf("error preparing query SelectTransactionItems: %w", err)
+	}
+	if q.selectTransactionStatusHistoryStmt, err = db.PrepareContext(ctx, selectTransactionStatusHistory); err != nil {
+		return nil, fmt.Errorf("error preparing query SelectTransactionStatusHistory: %w", err)
+	}
 	if q.selectTransactionsStmt, err = db.PrepareContext(ctx, selectTransactions); err != nil {
 		return nil, fmt.Errorf("error preparing query SelectTransactions: %w", err)
 	}
 	if q.selectTransactionsTotalStmt, err = db.PrepareContext(ctx, selectTransactionsTotal); err != nil {
 		return nil, fmt.Errorf("error preparing query SelectTransactionsTotal: %w", err)
 	}
+	if q.transitionTransactionStatusStmt, err = db.PrepareContext(ctx, transitionTransactionStatus); err != nil {
+		return nil, fmt.Errorf("error preparing query TransitionTransactionStatus: %w", err)
+	}
+	if q.updateCustomerStmt, err = db.PrepareContext(ctx, updateCustomer); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateCustomer: %w", err)
+	}
+	if q.updateTransactionStmt, err = db.PrepareContext(ctx, updateTransaction); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateTransaction: %w", err)
+	}
+	if q.upsertConversionStmt, err = db.PrepareContext(ctx, upsertConversion); err != nil {
+		return nil, fmt.Errorf("error preparing query UpsertConversion: %w", err)
+	}
+	if q.upsertRateOfExchangeStmt, err = db.PrepareContext(ctx, upsertRateOfExchange); err != nil {
+		return nil, fmt.Errorf("error preparing query UpsertRateOfExchange: %w", err)
+	}
 	return &q, nil
 }
 
 func (q *Queries) Close() error {
 	var err error
+	if q.deleteConversionsStmt != nil {
+		if cerr := q.deleteConversionsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteConversionsStmt: %w", cerr)
+		}
+	}
+	if q.deleteCustomerStmt != nil {
+		if cerr := q.deleteCustomerStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteCustomerStmt: %w", cerr)
+		}
+	}
+	if q.deleteTransactionStmt != nil {
+		if cerr := q.deleteTransactionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteTransactionStmt: %w", cerr)
+		}
+	}
+	if q.insertCustomerStmt != nil {
+		if cerr := q.insertCustomerStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing insertCustomerStmt: %w", cerr)
+		}
+	}
+	if q.insertQuoteStmt != nil {
+		if cerr := q.insertQuoteStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing insertQuoteStmt: %w", cerr)
+		}
+	}
+	if q.insertRefundStmt != nil {
+		if cerr := q.insertRefundStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing insertRefundStmt: %w", cerr)
+		}
+	}
 	if q.insertTransactionStmt != nil {
 		if cerr := q.insertTransactionStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing insertTransactionStmt: %w", cerr)
 		}
 	}
+	if q.restoreTransactionStmt != nil {
+		if cerr := q.restoreTransactionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing restoreTransactionStmt: %w", cerr)
+		}
+	}
+	if q.selectConversionsStmt != nil {
+		if cerr := q.selectConversionsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing selectConversionsStmt: %w", cerr)
+		}
+	}
+	if q.selectCurrenciesStmt != nil {
+		if cerr := q.selectCurrenciesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing selectCurrenciesStmt: %w", cerr)
+		}
+	}
+	if q.selectCurrencyByNameStmt != nil {
+		if cerr := q.selectCurrencyByNameStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing selectCurrencyByNameStmt: %w", cerr)
+		}
+	}
+	if q.selectCustomerByIDStmt != nil {
+		if cerr := q.selectCustomerByIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing selectCustomerByIDStmt: %w", cerr)
+		}
+	}
+	if q.selectCustomerTransactionsStmt != nil {
+		if cerr := q.selectCustomerTransactionsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing selectCustomerTransactionsStmt: %w", cerr)
+		}
+	}
+	if q.selectCustomersStmt != nil {
+		if cerr := q.selectCustomersStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing selectCustomersStmt: %w", cerr)
+		}
+	}
+	if q.selectCustomersTotalStmt != nil {
+		if cerr := q.selectCustomersTotalStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing selectCustomersTotalStmt: %w", cerr)
+		}
+	}
+	if q.selectQuoteByIDStmt != nil {
+		if cerr := q.selectQuoteByIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing selectQuoteByIDStmt: %w", cerr)
+		}
+	}
+	if q.selectRatesOfExchangeStmt != nil {
+		if cerr := q.selectRatesOfExchangeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing selectRatesOfExchangeStmt: %w", cerr)
+		}
+	}
+	if q.selectRatesOfExchangeHistoryStmt != nil {
+		if cerr := q.selectRatesOfExchangeHistoryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing selectRatesOfExchangeHistoryStmt: %w", cerr)
+		}
+	}
+	if q.selectRatesOfExchangeHistoryTotalStmt != nil {
+		if cerr := q.selectRatesOfExchangeHistoryTotalStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing selectRatesOfExchangeHistoryTotalStmt: %w", cerr)
+		}
+	}
+	if q.selectRatesOfExchangeLastRecordDateStmt != nil {
+		if cerr := q.selectRatesOfExchangeLastRecordDateStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing selectRatesOfExchangeLastRecordDateStmt: %w", cerr)
+		}
+	}
+	if q.selectRefundsStmt != nil {
+		if cerr := q.selectRefundsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing selectRefundsStmt: %w", cerr)
+		}
+	}
+	if q.selectRefundsTotalStmt != nil {
+		if cerr := q.selectRefundsTotalStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing selectRefundsTotalStmt: %w", cerr)
+		}
+	}
 	if q.selectTransactionByIDStmt != nil {
 		if cerr := q.selectTransactionByIDStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing selectTransactionByIDStmt: %w", cerr)
 		}
 	}
+	if q.selectTransactionForUpdateStmt != nil {
+		if cerr := q.selectTransactionForUpdateStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing selectTransactionForUpdateStmt: %w", cerr)
+		}
+	}
+	if q.selectTransactionItemsStmt != nil {
+		if cerr := q.selectTransactionItemsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing selectTransactionItemsStmt: %w", cerr)
+		}
+	}
+	if q.selectTransactionStatusHistoryStmt != nil {
+		if cerr := q.selectTransactionStatusHistoryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing selectTransactionStatusHistoryStmt: %w", cerr)
+		}
+	}
 	if q.selectTransactionsStmt != nil {
 		if cerr := q.selectTransactionsStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing selectTransactionsStmt: %w", cerr)
@@ -61,6 +268,31 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing selectTransactionsTotalStmt: %w", cerr)
 		}
 	}
+	if q.transitionTransactionStatusStmt != nil {
+		if cerr := q.transitionTransactionStatusStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing transitionTransactionStatusStmt: %w", cerr)
+		}
+	}
+	if q.updateCustomerStmt != nil {
+		if cerr := q.updateCustomerStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateCustomerStmt: %w", cerr)
+		}
+	}
+	if q.updateTransactionStmt != nil {
+		if cerr := q.updateTransactionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateTransactionStmt: %w", cerr)
+		}
+	}
+	if q.upsertConversionStmt != nil {
+		if cerr := q.upsertConversionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing upsertConversionStmt: %w", cerr)
+		}
+	}
+	if q.upsertRateOfExchangeStmt != nil {
+		if cerr := q.upsertRateOfExchangeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing upsertRateOfExchangeStmt: %w", cerr)
+		}
+	}
 	return err
 }
 
@@ -98,21 +330,79 @@ func (q *Queries) queryRow(ctx context.Context, stmt *sql.Stmt, query string, ar
 }
 
 type Queries struct {
-	db                          DBTX
-	tx                          *sql.Tx
-	insertTransactionStmt       *sql.Stmt
-	selectTransactionByIDStmt   *sql.Stmt
-	selectTransactionsStmt      *sql.Stmt
-	selectTransactionsTotalStmt *sql.Stmt
+	db                                      DBTX
+	tx                                      *sql.Tx
+	deleteConversionsStmt                   *sql.Stmt
+	deleteCustomerStmt                      *sql.Stmt
+	deleteTransactionStmt                   *sql.Stmt
+	insertCustomerStmt                      *sql.Stmt
+	insertQuoteStmt                         *sql.Stmt
+	insertRefundStmt                        *sql.Stmt
+	insertTransactionStmt                   *sql.Stmt
+	restoreTransactionStmt                  *sql.Stmt
+	selectConversionsStmt                   *sql.Stmt
+	selectCurrenciesStmt                    *sql.Stmt
+	selectCurrencyByNameStmt                *sql.Stmt
+	selectCustomerByIDStmt                  *sql.Stmt
+	selectCustomerTransactionsStmt          *sql.Stmt
+	selectCustomersStmt                     *sql.Stmt
+	selectCustomersTotalStmt                *sql.Stmt
+	selectQuoteByIDStmt                     *sql.Stmt
+	selectRatesOfExchangeStmt               *sql.Stmt
+	selectRatesOfExchangeHistoryStmt        *sql.Stmt
+	selectRatesOfExchangeHistoryTotalStmt   *sql.Stmt
+	selectRatesOfExchangeLastRecordDateStmt *sql.Stmt
+	selectRefundsStmt                       *sql.Stmt
+	selectRefundsTotalStmt                  *sql.Stmt
+	selectTransactionByIDStmt               *sql.Stmt
+	selectTransactionForUpdateStmt          *sql.Stmt
+	selectTransactionItemsStmt              *sql.Stmt
+	selectTransactionStatusHistoryStmt      *sql.Stmt
+	selectTransactionsStmt                  *sql.Stmt
+	selectTransactionsTotalStmt             *sql.Stmt
+	transitionTransactionStatusStmt         *sql.Stmt
+	updateCustomerStmt                      *sql.Stmt
+	updateTransactionStmt                   *sql.Stmt
+	upsertConversionStmt                    *sql.Stmt
+	upsertRateOfExchangeStmt                *sql.Stmt
 }
 
 func (q *Queries) WithTx(tx *sql.Tx) *Queries {
 	return &Queries{
-		db:                          tx,
-		tx:                          tx,
-		insertTransactionStmt:       q.insertTransactionStmt,
-		selectTransactionByIDStmt:   q.selectTransactionByIDStmt,
-		selectTransactionsStmt:      q.selectTransactionsStmt,
-		selectTransactionsTotalStmt: q.selectTransactionsTotalStmt,
+		db:                                      tx,
+		tx:                                      tx,
+		deleteConversionsStmt:                   q.deleteConversionsStmt,
+		deleteCustomerStmt:                      q.deleteCustomerStmt,
+		deleteTransactionStmt:                   q.deleteTransactionStmt,
+		insertCustomerStmt:                      q.insertCustomerStmt,
+		insertQuoteStmt:                         q.insertQuoteStmt,
+		insertRefundStmt:                        q.insertRefundStmt,
+		insertTransactionStmt:                   q.insertTransactionStmt,
+		restoreTransactionStmt:                  q.restoreTransactionStmt,
+		selectConversionsStmt:                   q.selectConversionsStmt,
+		selectCurrenciesStmt:                    q.selectCurrenciesStmt,
+		selectCurrencyByNameStmt:                q.selectCurrencyByNameStmt,
+		selectCustomerByIDStmt:                  q.selectCustomerByIDStmt,
+		selectCustomerTransactionsStmt:          q.selectCustomerTransactionsStmt,
+		selectCustomersStmt:                     q.selectCustomersStmt,
+		selectCustomersTotalStmt:                q.selectCustomersTotalStmt,
+		selectQuoteByIDStmt:                     q.selectQuoteByIDStmt,
+		selectRatesOfExchangeStmt:               q.selectRatesOfExchangeStmt,
+		selectRatesOfExchangeHistoryStmt:        q.selectRatesOfExchangeHistoryStmt,
+		selectRatesOfExchangeHistoryTotalStmt:   q.selectRatesOfExchangeHistoryTotalStmt,
+		selectRatesOfExchangeLastRecordDateStmt: q.selectRatesOfExchangeLastRecordDateStmt,
+		selectRefundsStmt:                       q.selectRefundsStmt,
+		selectRefundsTotalStmt:                  q.selectRefundsTotalStmt,
+		selectTransactionByIDStmt:               q.selectTransactionByIDStmt,
+		selectTransactionForUpdateStmt:          q.selectTransactionForUpdateStmt,
+		selectTransactionItemsStmt:              q.selectTransactionItemsStmt,
+		selectTransactionStatusHistoryStmt:      q.selectTransactionStatusHistoryStmt,
+		selectTransactionsStmt:                  q.selectTransactionsStmt,
+		selectTransactionsTotalStmt:             q.selectTransactionsTotalStmt,
+		transitionTransactionStatusStmt:         q.transitionTransactionStatusStmt,
+		updateCustomerStmt:                      q.updateCustomerStmt,
+		updateTransactionStmt:                   q.updateTransactionStmt,
+		upsertConversionStmt:                    q.upsertConversionStmt,
+		upsertRateOfExchangeStmt:                q.upsertRateOfExchangeStmt,
 	}
 }
@@ -5,12 +5,97 @@
 package sqlc
 
 import (
+	"database/sql"
 	"time"
+
+	"github.com/shopspring/decimal"
 )
 
+type Conversion struct {
+	ID                    int64
+	OrderID               int64
+	Currency              string
+	ExchangeRate          decimal.Decimal
+	EffectiveDate         time.Time
+	RecordDate            sql.NullTime
+	ConvertedExchangeRate decimal.Decimal
+	ConvertedValue        decimal.Decimal
+	CreatedAt             time.Time
+}
+
+type Customer struct {
+	ID        int64
+	Name      string
+	Email     string
+	CreatedAt time.Time
+}
+
 type Order struct {
-	ID               int64
-	Description      string
-	TransactionDate  time.Time
-	TransactionValue float64
+	ID                   int64
+	Description          string
+	TransactionDate      time.Time
+	TransactionValue     decimal.Decimal
+	Currency             string
+	UsdExchangeRate      decimal.Decimal
+	TransactionValueUsd  decimal.Decimal
+	QuoteID              sql.NullInt64
+	UsdRateEffectiveDate sql.NullTime
+	DeletedAt            sql.NullTime
+	Status               string
+	CustomerID           sql.NullInt64
+}
+
+type OrderItem struct {
+	ID        int64
+	OrderID   int64
+	Sku       string
+	Name      string
+	Quantity  int32
+	UnitPrice decimal.Decimal
+}
+
+type OrderStatusHistory struct {
+	ID         int64
+	OrderID    int64
+	FromStatus string
+	ToStatus   string
+	Reason     string
+	CreatedAt  time.Time
+}
+
+type Quote struct {
+	ID              int64
+	Currency        string
+	Amount          decimal.Decimal
+	ExchangeRate    decimal.Decimal
+	ConvertedAmount decimal.Decimal
+	EffectiveDate   sql.NullTime
+	CreatedAt       time.Time
+	ExpiresAt       time.Time
+}
+
+type Refund struct {
+	ID         int64
+	OrderID    int64
+	Amount     decimal.Decimal
+	Reason     string
+	RefundDate time.Time
+	CreatedAt  time.Time
+}
+
+type RatesOfExchange struct {
+	ID                    int64
+	RecordDate            time.Time
+	Country               string
+	Currency              string
+	CountryCurrencyDesc   string
+	ExchangeRate          decimal.Decimal
+	EffectiveDate         time.Time
+	SrcLineNbr            string
+	RecordFiscalYear      string
+	RecordFiscalQuarter   string
+	RecordCalendarYear    string
+	RecordCalendarQuarter string
+	RecordCalendarMonth   string
+	RecordCalendarDay     string
 }
@@ -6,14 +6,97 @@ package sqlc
 
 import (
 	"context"
+
+	"github.com/shopspring/decimal"
 )
 
 type Querier interface {
+	//---------------
+	//-- SELECTS ----
+	//---------------
+	//---------------
+	//-- DELETES ----
+	//---------------
+	DeleteConversions(ctx context.Context, orderID int64) error
+	//---------------
+	//-- UPDATES ----
+	//---------------
+	//---------------
+	//-- DELETES ----
+	//---------------
+	DeleteCustomer(ctx context.Context, id int64) (int64, error)
+	DeleteTransaction(ctx context.Context, id int64) (int64, error)
+	//---------------
+	//-- INSERTS ----
+	//---------------
+	InsertCustomer(ctx context.Context, arg InsertCustomerParams) (int64, error)
+	//---------------
+	//-- INSERTS ----
+	//---------------
+	InsertQuote(ctx context.Context, arg InsertQuoteParams) (InsertQuoteRow, error)
+	//---------------
+	//-- INSERTS ----
+	//---------------
+	InsertRefund(ctx context.Context, arg InsertRefundParams) (int64, error)
 	//---------------
 	//-- INSERTS ----
 	//---------------
 	InsertTransaction(ctx context.Context, arg InsertTransactionParams) (int64, error)
-	SelectTransactionByID(ctx context.Context, id int64) (SelectTransactionByIDRow, error)
+	RestoreTransaction(ctx context.Context, id int64) (int64, error)
+	//---------------
+	//-- UPSERTS ----
+	//---------------
+	//---------------
+	//-- SELECTS ----
+	//---------------
+	SelectConversions(ctx context.Context, arg SelectConversionsParams) ([]SelectConversionsRow, error)
+	SelectCurrencies(ctx context.Context, name string) ([]SelectCurrenciesRow, error)
+	SelectCurrencyByName(ctx context.Context, name string) (SelectCurrencyByNameRow, error)
+	SelectCustomerByID(ctx context.Context, id int64) (Customer, error)
+	SelectCustomerTransactions(ctx context.Context, arg SelectCustomerTransactionsParams) ([]SelectCustomerTransactionsRow, error)
+	//---------------
+	//-- INSERTS ----
+	//---------------
+	//---------------
+	//-- SELECTS ----
+	//---------------
+	SelectCustomers(ctx context.Context, arg SelectCustomersParams) ([]Customer, error)
+	SelectCustomersTotal(ctx context.Context) (int64, error)
+	//---------------
+	//-- INSERTS ----
+	//---------------
+	//---------------
+	//-- SELECTS ----
+	//---------------
+	SelectQuoteByID(ctx context.Context, id int64) (SelectQuoteByIDRow, error)
+	//---------------
+	//-- UPSERTS ----
+	//---------------
+	//---------------
+	//-- SELECTS ----
+	//---------------
+	SelectRatesOfExchange(ctx context.Context, arg SelectRatesOfExchangeParams) ([]SelectRatesOfExchangeRow, error)
+	SelectRatesOfExchangeHistory(ctx context.Context, arg SelectRatesOfExchangeHistoryParams) ([]SelectRatesOfExchangeHistoryRow, error)
+	SelectRatesOfExchangeHistoryTotal(ctx context.Context, arg SelectRatesOfExchangeHistoryTotalParams) (int64, error)
+	SelectRatesOfExchangeLastRecordDate(ctx context.Context) (string, error)
+	//---------------
+	//-- INSERTS ----
+	//---------------
+	//---------------
+	//-- SELECTS ----
+	//---------------
+	SelectRefunds(ctx context.Context, orderID int64) ([]Refund, error)
+	SelectRefundsTotal(ctx context.Context, orderID int64) (decimal.Decimal, error)
+	SelectTransactionByID(ctx context.Context, arg SelectTransactionByIDParams) (SelectTransactionByIDRow, error)
+	SelectTransactionForUpdate(ctx context.Context, id int64) (SelectTransactionForUpdateRow, error)
+	SelectTransactionItems(ctx context.Context, orderID int64) ([]OrderItem, error)
+	//---------------
+	//-- UPDATES ----
+	//---------------
+	//---------------
+	//-- SELECTS ----
+	//---------------
+	SelectTransactionStatusHistory(ctx context.Context, orderID int64) ([]OrderStatusHistory, error)
 	//---------------
 	//-- INSERTS ----
 	//---------------
@@ -21,7 +104,33 @@ type Querier interface {
 	//-- SELECTS ----
 	//---------------
 	SelectTransactions(ctx context.Context, arg SelectTransactionsParams) ([]SelectTransactionsRow, error)
-	SelectTransactionsTotal(ctx context.Context, transactionDate string) (int64, error)
+	SelectTransactionsTotal(ctx context.Context, arg SelectTransactionsTotalParams) (int64, error)
+	//---------------
+	//-- UPDATES ----
+	//---------------
+	TransitionTransactionStatus(ctx context.Context, arg TransitionTransactionStatusParams) (TransitionTransactionStatusRow, error)
+	//---------------
+	//-- SELECTS ----
+	//---------------
+	//---------------
+	//-- UPDATES ----
+	//---------------
+	UpdateCustomer(ctx context.Context, arg UpdateCustomerParams) (int64, error)
+	//---------------
+	//-- SELECTS ----
+	//---------------
+	//---------------
+	//-- UPDATES ----
+	//---------------
+	UpdateTransaction(ctx context.Context, arg UpdateTransactionParams) (int64, error)
+	//---------------
+	//-- UPSERTS ----
+	//---------------
+	UpsertConversion(ctx context.Context, arg UpsertConversionParams) error
+	//---------------
+	//-- UPSERTS ----
+	//---------------
+	UpsertRateOfExchange(ctx context.Context, arg UpsertRateOfExchangeParams) (bool, error)
 }
 
 var _ Querier = (*Queries)(nil)
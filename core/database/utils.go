@@ -1,6 +1,10 @@
 package database
 
 import (
+	"errors"
+	"strings"
+
+	"github.com/lib/pq"
 	coreError "github.com/luancpereira/APICheckout/core/errors"
 )
 
@@ -9,3 +13,25 @@ type Utils struct{}
 func (Utils) CoreErrorDatabase(err error) *coreError.CoreError {
 	return coreError.New("error.database", err.Error())
 }
+
+// IsUniqueViolation tells whether err was returned by an insert or update
+// that violates a unique constraint.
+func (Utils) IsUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+
+	return errors.As(err, &pqErr) && pqErr.Code == "23505"
+}
+
+// IsForeignKeyViolation tells whether err was returned by a statement that
+// references a missing row or deletes a referenced one.
+func (Utils) IsForeignKeyViolation(err error) bool {
+	var pqErr *pq.Error
+
+	return errors.As(err, &pqErr) && pqErr.Code == "23503"
+}
+
+// EscapeLike escapes the wildcards of a LIKE pattern, so value matches only
+// itself. The backslash is the default escape character of Postgres.
+func (Utils) EscapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+}
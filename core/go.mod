@@ -5,6 +5,7 @@ go 1.22.0
 require (
 	github.com/jellydator/ttlcache/v3 v3.2.0
 	github.com/lib/pq v1.10.9
+	github.com/shopspring/decimal v1.4.0
 	github.com/sirupsen/logrus v1.9.3
 	golang.org/x/text v0.3.3
 )
@@ -0,0 +1,44 @@
+package money
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+// Scale is the number of decimal places money amounts are stored with.
+const Scale = 2
+
+type RoundingMode int
+
+const (
+	// HalfUp rounds ties away from zero, 2.345 to 2.35 and -2.345 to -2.35.
+	HalfUp RoundingMode = iota
+	// HalfEven rounds ties to the even neighbour, 2.345 to 2.34 and 2.355 to
+	// 2.36, so ties do not drift in one direction across many amounts.
+	HalfEven
+)
+
+// Round rounds the amount to places decimal places with the given mode.
+func Round(amount decimal.Decimal, places int32, mode RoundingMode) decimal.Decimal {
+	switch mode {
+	case HalfEven:
+		return amount.RoundBank(places)
+	default:
+		return amount.Round(places)
+	}
+}
+
+// RoundSignificant rounds the amount to digits significant digits with the
+// given mode, for rates whose magnitude varies too much for a fixed number of
+// decimal places, such as 0.0058 and 157.2.
+func RoundSignificant(amount decimal.Decimal, digits int32, mode RoundingMode) decimal.Decimal {
+	if amount.IsZero() {
+		return amount
+	}
+
+	return Round(amount, digits-int32(amount.NumDigits())-amount.Exponent(), mode)
+}
+
+// RoundMoney rounds the amount to Scale decimal places with the given mode.
+func RoundMoney(amount decimal.Decimal, mode RoundingMode) decimal.Decimal {
+	return Round(amount, Scale, mode)
+}
@@ -0,0 +1,40 @@
+package money_test
+
+import (
+	"testing"
+
+	"github.com/luancpereira/APICheckout/core/money"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRound(t *testing.T) {
+	t.Run("Deve arredondar empates para longe do zero no modo HalfUp", func(t *testing.T) {
+		assert.Equal(t, "2.35", money.RoundMoney(decimal.RequireFromString("2.345"), money.HalfUp).String())
+		assert.Equal(t, "-2.35", money.RoundMoney(decimal.RequireFromString("-2.345"), money.HalfUp).String())
+	})
+
+	t.Run("Deve arredondar empates para o par no modo HalfEven", func(t *testing.T) {
+		assert.Equal(t, "2.34", money.RoundMoney(decimal.RequireFromString("2.345"), money.HalfEven).String())
+		assert.Equal(t, "2.36", money.RoundMoney(decimal.RequireFromString("2.355"), money.HalfEven).String())
+	})
+
+	t.Run("Não deve acumular erro de ponto flutuante", func(t *testing.T) {
+		total := decimal.Zero
+		for i := 0; i < 10; i++ {
+			total = total.Add(decimal.RequireFromString("0.1"))
+		}
+
+		assert.True(t, total.Equal(decimal.NewFromInt(1)))
+		assert.Equal(t, "1.01", money.Round(decimal.RequireFromString("1.005"), 2, money.HalfUp).String())
+	})
+}
+
+func TestRoundSignificant(t *testing.T) {
+	t.Run("Deve manter os dígitos significativos de taxas pequenas e grandes", func(t *testing.T) {
+		assert.Equal(t, "0.005813941", money.RoundSignificant(decimal.RequireFromString("5.434").Div(decimal.RequireFromString("934.65")), 8, money.HalfEven).String())
+		assert.Equal(t, "157.2", money.RoundSignificant(decimal.RequireFromString("157.2"), 8, money.HalfEven).String())
+		assert.Equal(t, "1.1394921", money.RoundSignificant(decimal.RequireFromString("6.192").Div(decimal.RequireFromString("5.434")), 8, money.HalfEven).String())
+		assert.Equal(t, "0", money.RoundSignificant(decimal.Zero, 8, money.HalfEven).String())
+	})
+}
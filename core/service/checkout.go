@@ -2,91 +2,431 @@ package service
 
 import (
 	"context"
-	"encoding/json"
+	"database/sql"
+	"errors"
 	"fmt"
 	"math"
-	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/luancpereira/APICheckout/core/currency"
 	"github.com/luancpereira/APICheckout/core/database"
 	"github.com/luancpereira/APICheckout/core/database/sqlc"
 	coreError "github.com/luancpereira/APICheckout/core/errors"
-	"golang.org/x/text/cases"
-	"golang.org/x/text/language"
+	"github.com/luancpereira/APICheckout/core/money"
+	"github.com/shopspring/decimal"
 )
 
-type Checkout struct{}
+type Checkout struct {
+	RateProvider   ExchangeRateProvider
+	RatePolicy     *RatePolicy
+	Refresh        bool
+	IncludeDeleted bool
+}
 
 /*****
 funcs for creations
 ******/
 
-func (c Checkout) CreateTransaction(description string, transaction_date time.Time, transaction_value float64) (ID int64, err error) {
+// CreateTransaction stores the order in its own currency, USD when empty,
+// together with the USD amount at the rate of the transaction date. The value
+// is rounded half-up to cents and the USD amount half-even.
+func (c Checkout) CreateTransaction(description string, transaction_date time.Time, transaction_value decimal.Decimal, transaction_currency string) (ID int64, err error) {
+	return c.CreateTransactionWithItems(description, transaction_date, transaction_value, transaction_currency, nil, 0)
+}
+
+// CreateTransactionWithItems stores the order as CreateTransaction together
+// with its items and, when customerID is set, its customer. With items the
+// value of the order is their total and transaction_value, when set, must
+// match it.
+func (c Checkout) CreateTransactionWithItems(description string, transaction_date time.Time, transaction_value decimal.Decimal, transaction_currency string, items []TransactionItem, customerID int64) (ID int64, err error) {
 
 	err = c.ValidateDescription(description)
 	if err != nil {
 		return
 	}
 
+	transaction_value, items, err = c.prepareItems(transaction_value, items)
+	if err != nil {
+		return
+	}
+
 	err = c.ValidateTrasactionValue(transaction_value)
 	if err != nil {
 		return
 	}
 
-	if math.Round(transaction_value*100) != transaction_value*100 {
-		transaction_value = math.Round(transaction_value*100) / 100
+	transaction_value = money.RoundMoney(transaction_value, money.HalfUp)
+
+	if !coreError.StringIsNotEmpty(transaction_currency) {
+		transaction_currency = currency.USD
+	}
+
+	orderCurrency, err := currency.ResolveCode(transaction_currency)
+	if err != nil {
+		return
+	}
+
+	usdExchangeRate, record, err := c.getExchangeRate(transaction_date, orderCurrency)
+	if err != nil {
+		return
 	}
 
 	params := sqlc.InsertTransactionParams{
-		Description:      description,
-		TransactionDate:  transaction_date,
-		TransactionValue: transaction_value,
+		Description:          description,
+		TransactionDate:      transaction_date,
+		TransactionValue:     transaction_value,
+		Currency:             orderCurrency.Code,
+		UsdExchangeRate:      usdExchangeRate,
+		TransactionValueUsd:  money.RoundMoney(transaction_value.Div(usdExchangeRate), money.HalfEven),
+		UsdRateEffectiveDate: record.EffectiveDate,
+		CustomerID:           customerID,
 	}
 
+	setItems(&params, items)
+
+	return c.insertTransaction(params)
+}
+
+func (Checkout) insertTransaction(params sqlc.InsertTransactionParams) (ID int64, err error) {
 	ID, err = database.DB_QUERIER.InsertTransaction(context.Background(), params)
+	if err == nil {
+		return
+	}
+
+	// the quote was checked before the insert, the unique constraint on
+	// order.quote_id only catches concurrent orders locked to the same quote
+	quoteUsed := params.QuoteID != 0 && database.Utils{}.IsUniqueViolation(err)
+	if quoteUsed {
+		err = coreError.New("error.quote.already.used", strconv.FormatInt(params.QuoteID, 10))
+		return
+	}
+
+	// the customer is not read before the insert, the foreign key on
+	// order.customer_id tells whether it exists
+	customerMissing := params.CustomerID != 0 && database.Utils{}.IsForeignKeyViolation(err)
+	if customerMissing {
+		err = coreError.New("error.customer.not.found", strconv.FormatInt(params.CustomerID, 10))
+		return
+	}
+
+	err = database.Utils{}.CoreErrorDatabase(err)
+
+	return
+}
+
+/*****
+funcs for creations
+******/
+
+/*****
+funcs for updates
+******/
+
+// UpdateTransaction applies the fields set in update to the order. A new date
+// takes the rate of that date, unless the order is locked to a quote, and a
+// new date or value recomputes the USD amount and drops the stored
+// conversions of the order. The date and the value only change while the
+// order is pending or paid.
+func (c Checkout) UpdateTransaction(ID int64, update TransactionUpdate) (err error) {
+	if update.Description != nil {
+		err = c.ValidateDescription(*update.Description)
+		if err != nil {
+			return
+		}
+	}
+
+	if update.TransactionValue != nil {
+		err = c.ValidateTrasactionValue(*update.TransactionValue)
+		if err != nil {
+			return
+		}
+	}
+
+	// the order row stays locked until the transaction ends, so no refund or
+	// status change happens between the checks and the update
+	return database.DB_TRANSACTION(func(querier sqlc.Querier) (err error) {
+		locked, err := c.lockTransaction(querier, ID)
+		if err != nil {
+			return
+		}
+
+		current, err := c.selectTransaction(querier, ID, false)
+		if err != nil {
+			return
+		}
+
+		params := sqlc.UpdateTransactionParams{
+			ID:                   current.ID,
+			Description:          current.Description,
+			TransactionDate:      current.TransactionDate,
+			TransactionValue:     current.TransactionValue,
+			UsdExchangeRate:      current.UsdExchangeRate,
+			TransactionValueUsd:  current.TransactionValueUsd,
+			UsdRateEffectiveDate: current.UsdRateEffectiveDate,
+		}
+
+		if update.Description != nil {
+			params.Description = *update.Description
+		}
+
+		if update.TransactionValue != nil {
+			params.TransactionValue = money.RoundMoney(*update.TransactionValue, money.HalfUp)
+		}
+
+		if update.TransactionDate != nil {
+			params.TransactionDate = *update.TransactionDate
+		}
+
+		dateChanged := !truncateToDay(params.TransactionDate).Equal(truncateToDay(current.TransactionDate))
+		valueChanged := !params.TransactionValue.Equal(current.TransactionValue)
+
+		if (dateChanged || valueChanged) && locked.Status != StatusPending && locked.Status != StatusPaid {
+			err = coreError.New("error.transaction.status.readonly", locked.Status)
+			return
+		}
+
+		if valueChanged {
+			err = c.validateValueUpdate(querier, ID, params.TransactionValue)
+			if err != nil {
+				return
+			}
+		}
+
+		if dateChanged && current.QuoteID == 0 {
+			orderCurrency, errResolve := currency.Resolve(current.Currency)
+			if errResolve != nil {
+				err = errResolve
+				return
+			}
+
+			var record Record
+			params.UsdExchangeRate, record, err = c.getExchangeRate(params.TransactionDate, orderCurrency)
+			if err != nil {
+				return
+			}
+
+			params.UsdRateEffectiveDate = record.EffectiveDate
+		}
+
+		params.TransactionValueUsd = money.RoundMoney(params.TransactionValue.Div(params.UsdExchangeRate), money.HalfEven)
+
+		_, err = querier.UpdateTransaction(context.Background(), params)
+		if err != nil {
+			err = database.Utils{}.CoreErrorDatabase(err)
+			return
+		}
+
+		if dateChanged || valueChanged {
+			err = querier.DeleteConversions(context.Background(), ID)
+			if err != nil {
+				err = database.Utils{}.CoreErrorDatabase(err)
+				return
+			}
+		}
+
+		return
+	})
+}
+
+// validateValueUpdate checks that the order has no items, whose total is the
+// value, and that the new value still covers the refunds of the order.
+func (c Checkout) validateValueUpdate(querier sqlc.Querier, ID int64, value decimal.Decimal) (err error) {
+	items, err := querier.SelectTransactionItems(context.Background(), ID)
 	if err != nil {
 		err = database.Utils{}.CoreErrorDatabase(err)
 		return
 	}
 
+	if len(items) > 0 {
+		err = coreError.New("error.items.value.readonly")
+		return
+	}
+
+	refunded, err := c.getRefundedTotal(querier, ID)
+	if err != nil {
+		return
+	}
+
+	if value.LessThan(refunded) {
+		err = coreError.New("error.transaction.value.below.refunds", refunded.String())
+		return
+	}
+
 	return
 }
 
+// DeleteTransaction voids the order, keeping it for audit. Deleted orders are
+// left out of the queries unless IncludeDeleted is set.
+func (c Checkout) DeleteTransaction(ID int64) (err error) {
+	_, err = database.DB_QUERIER.DeleteTransaction(context.Background(), ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return c.deletedStateError(ID, "error.transaction.already.deleted")
+	}
+
+	if err != nil {
+		err = database.Utils{}.CoreErrorDatabase(err)
+		return
+	}
+
+	return
+}
+
+func (c Checkout) RestoreTransaction(ID int64) (err error) {
+	_, err = database.DB_QUERIER.RestoreTransaction(context.Background(), ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return c.deletedStateError(ID, "error.transaction.not.deleted")
+	}
+
+	if err != nil {
+		err = database.Utils{}.CoreErrorDatabase(err)
+		return
+	}
+
+	return
+}
+
+// deletedStateError tells apart an order that does not exist from one that
+// is already in the state a delete or restore would leave it in.
+func (c Checkout) deletedStateError(ID int64, key string) (err error) {
+	_, err = c.getTransaction(ID, true)
+	if err != nil {
+		return
+	}
+
+	return coreError.New(key, strconv.FormatInt(ID, 10))
+}
+
 /*****
-funcs for creations
+funcs for updates
 ******/
 
 /*****
 funcs for gets
 ******/
 
-func (Checkout) GetByID(transactionID int64, country string) (transaction TransactionDetail, err error) {
-	transactionDetail, err := database.DB_QUERIER.SelectTransactionByID(context.Background(), transactionID)
+// getTransaction returns the order, reporting error.transaction.not.found
+// when it does not exist or is deleted and includeDeleted is not set.
+func (c Checkout) getTransaction(ID int64, includeDeleted bool) (transaction sqlc.SelectTransactionByIDRow, err error) {
+	return c.selectTransaction(database.DB_QUERIER, ID, includeDeleted)
+}
+
+// selectTransaction is getTransaction through querier, for reads inside a
+// database transaction.
+func (Checkout) selectTransaction(querier sqlc.Querier, ID int64, includeDeleted bool) (transaction sqlc.SelectTransactionByIDRow, err error) {
+	params := sqlc.SelectTransactionByIDParams{
+		ID:             ID,
+		IncludeDeleted: includeDeleted,
+	}
+
+	transaction, err = querier.SelectTransactionByID(context.Background(), params)
+	if errors.Is(err, sql.ErrNoRows) {
+		err = coreError.New("error.transaction.not.found", strconv.FormatInt(ID, 10))
+		return
+	}
+
 	if err != nil {
 		err = database.Utils{}.CoreErrorDatabase(err)
 		return
 	}
 
-	exchangeRate, err := getExchangeRate(transactionDetail.TransactionDate, country)
+	return
+}
+
+func (c Checkout) GetByID(transactionID int64, country string, currencies []string) (transaction TransactionDetail, err error) {
+	targetCurrency, err := currency.Resolve(country)
+	if err != nil {
+		return
+	}
+
+	transactionDetail, err := c.getTransaction(transactionID, c.IncludeDeleted)
+	if err != nil {
+		return
+	}
+
+	primary := newConversionTarget(targetCurrency, nil)
+	targets := resolveConversionTargets(currencies)
+
+	snapshots, err := c.getConversionSnapshots([]int64{transactionDetail.ID}, append(targets, primary))
+	if err != nil {
+		return
+	}
+
+	order := orderAmount{
+		id:                   transactionDetail.ID,
+		transactionDate:      transactionDetail.TransactionDate,
+		currency:             transactionDetail.Currency,
+		value:                transactionDetail.TransactionValue,
+		usdExchangeRate:      transactionDetail.UsdExchangeRate,
+		usdRateEffectiveDate: transactionDetail.UsdRateEffectiveDate,
+	}
+
+	exchangeRate, converted, legs, err := c.convert(order, primary, snapshots)
 	if err != nil {
 		return
 	}
 
 	transaction = TransactionDetail{
 		SelectTransactionByIDRow:                transactionDetail,
-		ExchangeRate:                            math.Round(exchangeRate*100) / 100,
-		TransactionValueConvertedToWishCurrency: math.Round(transactionDetail.TransactionValue*exchangeRate*100) / 100,
+		ExchangeRate:                            exchangeRate,
+		TransactionValueConvertedToWishCurrency: converted,
+		Legs:                                    legs,
+		Stale:                                   legs.stale(),
+	}
+
+	for i, requested := range currencies {
+		transaction.Conversions = append(transaction.Conversions, c.newConversion(requested, order, targets[i], snapshots))
+	}
+
+	transaction.Items, err = c.getItems(transactionDetail.ID, legs)
+	if err != nil {
+		return
 	}
 
+	refunds, refunded, refundedConverted, err := c.getRefunds(transactionDetail, primary)
+	if err != nil {
+		return
+	}
+
+	transaction.Refunds = refunds
+	transaction.RefundedValue = refunded
+	transaction.NetValue = transactionDetail.TransactionValue.Sub(refunded)
+	transaction.NetValueConvertedToWishCurrency = converted.Sub(refundedConverted)
+
 	return
 }
 
-func (Checkout) GetList(filters map[string]string, limit, offset int64, country string) (models []TransactionDetailList, total int64, err error) {
+func (c Checkout) GetList(filters map[string]string, limit, offset int64, country string, currencies []string) (models []TransactionDetailList, total int64, err error) {
+	targetCurrency, err := currency.Resolve(country)
+	if err != nil {
+		return
+	}
+
+	status := strings.ToLower(strings.TrimSpace(filters["status"]))
+	if coreError.StringIsNotEmpty(status) {
+		err = c.ValidateStatus(status)
+		if err != nil {
+			return
+		}
+	}
+
+	var customerID int64
+	if coreError.StringIsNotEmpty(filters["customer_id"]) {
+		customerID, err = strconv.ParseInt(filters["customer_id"], 10, 64)
+		if err != nil {
+			err = coreError.New("error.request.query.param.invalid", "filter_customer_id")
+			return
+		}
+	}
+
 	params := sqlc.SelectTransactionsParams{
 		Column1:         limit,
 		Column2:         offset,
 		TransactionDate: filters["transaction_date"],
+		IncludeDeleted:  c.IncludeDeleted,
+		Status:          status,
+		CustomerID:      customerID,
 	}
 
 	transactions, err := database.DB_QUERIER.SelectTransactions(context.Background(), params)
@@ -95,59 +435,109 @@ func (Checkout) GetList(filters map[string]string, limit, offset int64, country
 		return
 	}
 
-	parsedDate, _ := time.Parse("2006-01-02", filters["transaction_date"])
+	models, err = c.convertTransactions(transactions, newConversionTarget(targetCurrency, nil), resolveConversionTargets(currencies), currencies)
+	if err != nil {
+		return
+	}
 
-	exchangeRate, err := getExchangeRate(parsedDate, country)
+	totalParams := sqlc.SelectTransactionsTotalParams{
+		TransactionDate: filters["transaction_date"],
+		IncludeDeleted:  c.IncludeDeleted,
+		Status:          status,
+		CustomerID:      customerID,
+	}
 
+	total, err = database.DB_QUERIER.SelectTransactionsTotal(context.Background(), totalParams)
 	if err != nil {
+		err = database.Utils{}.CoreErrorDatabase(err)
 		return
 	}
 
-	var transactionDetailList []TransactionDetailList
+	return
+}
+
+// convertTransactions converts the orders to the primary target and to the
+// extra targets resolved from currencies.
+func (c Checkout) convertTransactions(transactions []sqlc.SelectTransactionsRow, primary *conversionTarget, targets []*conversionTarget, currencies []string) (models []TransactionDetailList, err error) {
+	orderIDs := make([]int64, len(transactions))
+	for i, transaction := range transactions {
+		orderIDs[i] = transaction.ID
+	}
 
-	for _, transaction := range transactions {
+	snapshots, err := c.getConversionSnapshots(orderIDs, append(targets, primary))
+	if err != nil {
+		return
+	}
+
+	orders := make([]orderAmount, len(transactions))
+	for i, transaction := range transactions {
+		orders[i] = orderAmount{
+			id:                   transaction.ID,
+			transactionDate:      transaction.TransactionDate,
+			currency:             transaction.Currency,
+			value:                transaction.TransactionValue,
+			usdExchangeRate:      transaction.UsdExchangeRate,
+			usdRateEffectiveDate: transaction.UsdRateEffectiveDate,
+		}
+	}
+
+	c.resolveConversionRates(orders, append(targets, primary), snapshots)
+
+	for i, transaction := range transactions {
+		order := orders[i]
+
+		exchangeRate, converted, legs, errConvert := c.convert(order, primary, snapshots)
+		if errConvert != nil {
+			err = errConvert
+			return
+		}
 
 		transactionDetail := TransactionDetailList{
 			SelectTransactionsRow:                   transaction,
-			ExchangeRate:                            math.Round(exchangeRate*100) / 100,
-			TransactionValueConvertedToWishCurrency: math.Round(transaction.TransactionValue*exchangeRate*100) / 100,
+			ExchangeRate:                            exchangeRate,
+			TransactionValueConvertedToWishCurrency: converted,
+			Legs:                                    legs,
+			Stale:                                   legs.stale(),
 		}
 
-		transactionDetailList = append(transactionDetailList, transactionDetail)
-	}
-
-	models = transactionDetailList
+		for j, requested := range currencies {
+			transactionDetail.Conversions = append(transactionDetail.Conversions, c.newConversion(requested, order, targets[j], snapshots))
+		}
 
-	total, err = database.DB_QUERIER.SelectTransactionsTotal(context.Background(), filters["transaction_date"])
-	if err != nil {
-		err = database.Utils{}.CoreErrorDatabase(err)
-		return
+		models = append(models, transactionDetail)
 	}
 
 	return
 }
 
-func getExchangeRate(transactionDate time.Time, country string) (float64, error) {
-	formattedDate := transactionDate.Format("2006-01-02")
-	url := "https://api.fiscaldata.treasury.gov/services/api/fiscal_service/v1/accounting/od/rates_of_exchange?filter=country:eq:" + CapitalizeFirstLetter(country) + ",effective_date:lte:" + formattedDate
+// getExchangeRate returns the rate of the target currency per USD on the
+// transaction date and the Treasury record it comes from, empty for USD.
+func (c Checkout) getExchangeRate(transactionDate time.Time, targetCurrency currency.Currency) (decimal.Decimal, Record, error) {
+	if targetCurrency.IsUSD() {
+		return decimal.NewFromInt(1), Record{}, nil
+	}
 
-	var response Response
-	err := GetEntity(url, map[string]string{}, &response)
+	closestRecord, err := c.RateProvider.FindRecord(targetCurrency.Key, transactionDate, c.ratePolicy())
 	if err != nil {
-		return 0, err
+		return decimal.Zero, Record{}, err
 	}
 
-	closestRecord, err := FindRegistryWithDateCloset(response.Data, transactionDate)
-	if err != nil {
-		return 0, err
+	exchangeRate, err := decimal.NewFromString(closestRecord.ExchangeRate)
+	if err != nil || !exchangeRate.IsPositive() {
+		return decimal.Zero, Record{}, fmt.Errorf("erro ao converter ExchangeRate para decimal: %q", closestRecord.ExchangeRate)
 	}
 
-	exchangeRate, err := strconv.ParseFloat(closestRecord.ExchangeRate, 64)
-	if err != nil {
-		return 0, fmt.Errorf("erro ao converter ExchangeRate para float64: %w", err)
+	return exchangeRate, closestRecord, nil
+}
+
+// ratePolicy returns the policy overridden for this request or, when there is
+// none, the policy of the deployment.
+func (c Checkout) ratePolicy() RatePolicy {
+	if c.RatePolicy != nil {
+		return *c.RatePolicy
 	}
 
-	return exchangeRate, nil
+	return DefaultRatePolicy()
 }
 
 /*****
@@ -172,8 +562,8 @@ func (Checkout) ValidateDescription(description string) (err error) {
 	return
 }
 
-func (Checkout) ValidateTrasactionValue(value float64) (err error) {
-	if value <= 0 {
+func (Checkout) ValidateTrasactionValue(value decimal.Decimal) (err error) {
+	if !value.IsPositive() {
 		err = coreError.New("error.value.not.positive")
 		return
 	}
@@ -181,10 +571,20 @@ func (Checkout) ValidateTrasactionValue(value float64) (err error) {
 	return
 }
 
+// FindRegistryWithDateCloset selects the record that applies to targetDate
+// under the rate policy of the deployment.
 func FindRegistryWithDateCloset(records []Record, targetDate time.Time) (closestRecord Record, err error) {
+	return FindRegistryWithPolicy(records, targetDate, DefaultRatePolicy())
+}
+
+// FindRegistryWithPolicy selects, among the records the policy accepts, the
+// one whose effective date is closest to the day of targetDate. On a tie the
+// earlier record wins.
+func FindRegistryWithPolicy(records []Record, targetDate time.Time, policy RatePolicy) (closestRecord Record, err error) {
 	var minDiff time.Duration = time.Duration(math.MaxInt64)
+	var closestDate time.Time
 
-	const maxDuration = 182 * 24 * time.Hour
+	targetDay := truncateToDay(targetDate)
 
 	for _, record := range records {
 		recordDate, err := time.Parse("2006-01-02", record.EffectiveDate)
@@ -192,13 +592,18 @@ func FindRegistryWithDateCloset(records []Record, targetDate time.Time) (closest
 			continue
 		}
 
-		diff := recordDate.Sub(targetDate)
+		diff := recordDate.Sub(targetDay)
+		if !policy.accepts(diff) {
+			continue
+		}
+
 		if diff < 0 {
 			diff = -diff
 		}
 
-		if diff < minDiff && recordDate.Before(targetDate) && diff <= maxDuration {
+		if diff < minDiff || (diff == minDiff && recordDate.Before(closestDate)) {
 			minDiff = diff
+			closestDate = recordDate
 			closestRecord = record
 		}
 	}
@@ -218,16 +623,46 @@ funcs for validations
 other funcs
 ******/
 
+// TransactionUpdate holds the fields of a partial update, nil when unchanged.
+type TransactionUpdate struct {
+	Description      *string
+	TransactionDate  *time.Time
+	TransactionValue *decimal.Decimal
+}
+
 type TransactionDetail struct {
 	sqlc.SelectTransactionByIDRow
-	ExchangeRate                            float64
-	TransactionValueConvertedToWishCurrency float64
+	ExchangeRate                            decimal.Decimal
+	TransactionValueConvertedToWishCurrency decimal.Decimal
+	Legs                                    RateLegs
+	Stale                                   bool
+	Conversions                             []Conversion
+	Items                                   []TransactionItemDetail
+	Refunds                                 []Refund
+	RefundedValue                           decimal.Decimal
+	NetValue                                decimal.Decimal
+	NetValueConvertedToWishCurrency         decimal.Decimal
 }
 
 type TransactionDetailList struct {
 	sqlc.SelectTransactionsRow
-	ExchangeRate                            float64
-	TransactionValueConvertedToWishCurrency float64
+	ExchangeRate                            decimal.Decimal
+	TransactionValueConvertedToWishCurrency decimal.Decimal
+	Legs                                    RateLegs
+	Stale                                   bool
+	Conversions                             []Conversion
+}
+
+// Conversion is the value converted to one of the currencies requested
+// together with the path country. A currency that cannot be converted carries
+// its own Error instead of failing the whole response.
+type Conversion struct {
+	Currency                                string
+	ExchangeRate                            decimal.Decimal
+	TransactionValueConvertedToWishCurrency decimal.Decimal
+	Legs                                    *RateLegs
+	Stale                                   bool
+	Error                                   *coreError.CoreError
 }
 
 type Record struct {
@@ -247,44 +682,39 @@ type Record struct {
 }
 
 type Meta struct {
-	Count int `json:"count"`
+	Count      int `json:"count"`
+	TotalCount int `json:"total-count"`
+	TotalPages int `json:"total-pages"`
 }
 
-type Response struct {
-	Data []Record `json:"data"`
-	Meta Meta     `json:"meta"`
+// Links holds the query string suffixes the Treasury API returns to reach the
+// other pages of a response, e.g. "&page%5Bnumber%5D=2&page%5Bsize%5D=100".
+type Links struct {
+	Self  string `json:"self"`
+	First string `json:"first"`
+	Prev  string `json:"prev"`
+	Next  string `json:"next"`
+	Last  string `json:"last"`
 }
 
-func GetEntity(url string, headers map[string]string, target interface{}) error {
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return fmt.Errorf("erro ao criar a requisição: %w", err)
-	}
-
-	for key, value := range headers {
-		req.Header.Set(key, value)
-	}
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return fmt.Errorf("erro ao fazer a requisição: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("requisição falhou com status %d", resp.StatusCode)
-	}
+type Response struct {
+	Data  []Record `json:"data"`
+	Meta  Meta     `json:"meta"`
+	Links Links    `json:"links"`
+}
 
-	if err := json.NewDecoder(resp.Body).Decode(target); err != nil {
-		return fmt.Errorf("erro ao decodificar a resposta JSON: %w", err)
-	}
+// IsNotFound tells whether err reports that the resource addressed by the
+// request does not exist.
+func IsNotFound(err error) bool {
+	var coreErr *coreError.CoreError
 
-	return nil
+	return errors.As(err, &coreErr) && (coreErr.Key == "error.transaction.not.found" || coreErr.Key == "error.customer.not.found")
 }
 
-func CapitalizeFirstLetter(text string) string {
-	return cases.Title(language.Und, cases.Compact).String(text)
+// GetEntity GETs url with DefaultHTTPClient and decodes the JSON body into
+// target.
+func GetEntity(url string, headers map[string]string, target interface{}) error {
+	return DefaultHTTPClient.GetEntity(url, headers, target)
 }
 
 /*****
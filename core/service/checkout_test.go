@@ -8,14 +8,19 @@ import (
 	"time"
 
 	"github.com/jellydator/ttlcache/v3"
+	"github.com/luancpereira/APICheckout/core/currency"
+	"github.com/luancpereira/APICheckout/core/database"
+	"github.com/luancpereira/APICheckout/core/database/sqlc"
 	coreErrors "github.com/luancpereira/APICheckout/core/errors"
 	"github.com/luancpereira/APICheckout/core/service"
+	"github.com/luancpereira/APICheckout/core/service/servicetest"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 )
 
 type MockCheckout struct{}
 
-func (m *MockCheckout) CreateTransaction(description string, transactionDate time.Time, transactionValue float64) (int64, error) {
+func (m *MockCheckout) CreateTransaction(description string, transactionDate time.Time, transactionValue decimal.Decimal, transactionCurrency string) (int64, error) {
 	return 12345, nil
 }
 
@@ -28,18 +33,70 @@ func (m MockError) New(keys ...string) *coreErrors.CoreError {
 	}
 }
 
+type MockQuerier = servicetest.MockQuerier
+
 type MockResponse struct {
 	Name  string `json:"name"`
 	Value string `json:"value"`
 }
 
 func TestMain(m *testing.M) {
+	database.DB_TRANSACTION = func(fn func(querier sqlc.Querier) error) error {
+		return fn(database.DB_QUERIER)
+	}
+
 	coreErrors.C = ttlcache.New[string, string]()
 
 	coreErrors.C.Set("error.description.empty", "Description cannot be empty.", ttlcache.NoTTL)
 	coreErrors.C.Set("error.description.too.long", "Description must be less than 50 characters.", ttlcache.NoTTL)
 	coreErrors.C.Set("error.value.not.positive", "Value must be positive.", ttlcache.NoTTL)
 	coreErrors.C.Set("error.not.found.value.record", "Value cannot be converted to the currency.", ttlcache.NoTTL)
+	coreErrors.C.Set("error.currency.not.supported", "Currency not supported:", ttlcache.NoTTL)
+	coreErrors.C.Set("error.config.invalid", "Invalid configuration:", ttlcache.NoTTL)
+	coreErrors.C.Set("error.rate.invalid", "Invalid exchange rate in the rates data:", ttlcache.NoTTL)
+	coreErrors.C.Set("error.rate.policy.invalid", "Invalid rate policy:", ttlcache.NoTTL)
+	coreErrors.C.Set("error.upstream.unavailable", "Upstream service temporarily unavailable:", ttlcache.NoTTL)
+	coreErrors.C.Set("error.quote.not.found", "Quote not found:", ttlcache.NoTTL)
+	coreErrors.C.Set("error.quote.expired", "Quote has expired, request a new one:", ttlcache.NoTTL)
+	coreErrors.C.Set("error.quote.already.used", "Quote was already used by another order:", ttlcache.NoTTL)
+	coreErrors.C.Set("error.quote.currency.mismatch", "Order currency differs from the quote currency:", ttlcache.NoTTL)
+	coreErrors.C.Set("error.quote.amount.mismatch", "Order value differs from the quoted amount:", ttlcache.NoTTL)
+	coreErrors.C.Set("error.convert.date.invalid", "Invalid date, use YYYY-MM-DD:", ttlcache.NoTTL)
+	coreErrors.C.Set("error.transaction.not.found", "Transaction not found:", ttlcache.NoTTL)
+	coreErrors.C.Set("error.transaction.already.deleted", "Transaction is already deleted:", ttlcache.NoTTL)
+	coreErrors.C.Set("error.transaction.not.deleted", "Transaction is not deleted:", ttlcache.NoTTL)
+	coreErrors.C.Set("error.convert.batch.size", "The batch must have at least one item and at most:", ttlcache.NoTTL)
+	coreErrors.C.Set("error.refund.reason.empty", "Refund reason cannot be empty.", ttlcache.NoTTL)
+	coreErrors.C.Set("error.refund.reason.too.long", "Refund reason must be less than 255 characters.", ttlcache.NoTTL)
+	coreErrors.C.Set("error.refund.date.before.transaction", "Refund date cannot be before the transaction date:", ttlcache.NoTTL)
+	coreErrors.C.Set("error.refund.date.future", "Refund date cannot be after today:", ttlcache.NoTTL)
+	coreErrors.C.Set("error.refund.exceeds.value", "Refunds cannot exceed the transaction value, amount still refundable:", ttlcache.NoTTL)
+	coreErrors.C.Set("error.refund.status.invalid", "Only paid transactions can be refunded, current status:", ttlcache.NoTTL)
+	coreErrors.C.Set("error.transaction.value.below.refunds", "Transaction value cannot be less than the amount already refunded:", ttlcache.NoTTL)
+	coreErrors.C.Set("error.transaction.status.readonly", "Only pending or paid transactions can change date or value, current status:", ttlcache.NoTTL)
+	coreErrors.C.Set("error.status.invalid", "Invalid status, use pending, paid, cancelled or refunded:", ttlcache.NoTTL)
+	coreErrors.C.Set("error.status.reason.too.long", "Status reason must be less than 255 characters.", ttlcache.NoTTL)
+	coreErrors.C.Set("error.status.unchanged", "Transaction is already in the status:", ttlcache.NoTTL)
+	coreErrors.C.Set("error.status.final", "Transaction status is final and cannot change:", ttlcache.NoTTL)
+	coreErrors.C.Set("error.status.transition.invalid", "Status transition not allowed:", ttlcache.NoTTL)
+	coreErrors.C.Set("error.status.refunds.incomplete", "Transaction can only be refunded once the refunds cover its value, amount still refundable:", ttlcache.NoTTL)
+	coreErrors.C.Set("error.item.sku.empty", "Item SKU cannot be empty.", ttlcache.NoTTL)
+	coreErrors.C.Set("error.item.sku.too.long", "Item SKU must be less than 50 characters.", ttlcache.NoTTL)
+	coreErrors.C.Set("error.item.name.empty", "Item name cannot be empty.", ttlcache.NoTTL)
+	coreErrors.C.Set("error.item.name.too.long", "Item name must be less than 100 characters.", ttlcache.NoTTL)
+	coreErrors.C.Set("error.item.quantity.not.positive", "Item quantity must be positive.", ttlcache.NoTTL)
+	coreErrors.C.Set("error.items.total.mismatch", "Transaction value differs from the total of the items:", ttlcache.NoTTL)
+	coreErrors.C.Set("error.items.value.readonly", "Transaction value of an order with items is the total of the items and cannot be changed.", ttlcache.NoTTL)
+	coreErrors.C.Set("error.customer.not.found", "Customer not found:", ttlcache.NoTTL)
+	coreErrors.C.Set("error.customer.has.transactions", "Customer has transactions and cannot be removed:", ttlcache.NoTTL)
+	coreErrors.C.Set("error.customer.name.empty", "Customer name cannot be empty.", ttlcache.NoTTL)
+	coreErrors.C.Set("error.customer.name.too.long", "Customer name must be less than 100 characters.", ttlcache.NoTTL)
+	coreErrors.C.Set("error.customer.email.empty", "Customer email cannot be empty.", ttlcache.NoTTL)
+	coreErrors.C.Set("error.customer.email.too.long", "Customer email must be less than 255 characters.", ttlcache.NoTTL)
+	coreErrors.C.Set("error.customer.email.invalid", "Invalid customer email:", ttlcache.NoTTL)
+	coreErrors.C.Set("error.customer.email.already.used", "Email already belongs to another customer:", ttlcache.NoTTL)
+
+	service.DefaultHTTPClient = service.NewHTTPClient(time.Second, 1, time.Millisecond, 0, 0)
 
 	m.Run()
 }
@@ -48,11 +105,11 @@ func TestCreateTransaction(t *testing.T) {
 
 	description := "Test transaction"
 	transactionDate := time.Now()
-	transactionValue := 100.0
+	transactionValue := decimal.NewFromInt(100)
 
 	checkout := &MockCheckout{}
 
-	id, err := checkout.CreateTransaction(description, transactionDate, transactionValue)
+	id, err := checkout.CreateTransaction(description, transactionDate, transactionValue, "USD")
 
 	assert.NoError(t, err)
 
@@ -84,19 +141,19 @@ func TestValidateDescription(t *testing.T) {
 
 func TestValidateTransactionValue(t *testing.T) {
 	t.Run("Deve retornar erro para valor não positivo", func(t *testing.T) {
-		err := service.Checkout{}.ValidateTrasactionValue(0)
+		err := service.Checkout{}.ValidateTrasactionValue(decimal.Zero)
 		coreErr, ok := err.(*coreErrors.CoreError)
 		assert.True(t, ok, "O erro retornado deve ser do tipo CoreError")
 		assert.Equal(t, "error.value.not.positive", coreErr.Key)
 
-		err = service.Checkout{}.ValidateTrasactionValue(-10)
+		err = service.Checkout{}.ValidateTrasactionValue(decimal.NewFromInt(-10))
 		coreErr, ok = err.(*coreErrors.CoreError)
 		assert.True(t, ok, "O erro retornado deve ser do tipo CoreError")
 		assert.Equal(t, "error.value.not.positive", coreErr.Key)
 	})
 
 	t.Run("Deve retornar sucesso para valor positivo", func(t *testing.T) {
-		err := service.Checkout{}.ValidateTrasactionValue(100)
+		err := service.Checkout{}.ValidateTrasactionValue(decimal.NewFromInt(100))
 		assert.NoError(t, err)
 	})
 }
@@ -228,3 +285,392 @@ func TestFindRegistryWithDateCloset(t *testing.T) {
 		assert.Equal(t, "1.30", closestRecord.ExchangeRate)
 	})
 }
+
+func TestCreateTransactionCurrency(t *testing.T) {
+	var inserted sqlc.InsertTransactionParams
+	database.DB_QUERIER = MockQuerier{Inserted: &inserted}
+
+	checkout := service.Checkout{
+		RateProvider: service.FakeRateProvider{
+			Records: []service.Record{
+				{Country: "Brazil", CountryCurrencyDesc: "Brazil-Real", EffectiveDate: "2024-12-31", ExchangeRate: "6.192"},
+			},
+		},
+	}
+	transactionDate := time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Deve guardar a moeda do pedido e o valor em dólar", func(t *testing.T) {
+		_, err := checkout.CreateTransaction("Pedido", transactionDate, decimal.NewFromInt(100), "brl")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "BRL", inserted.Currency)
+		assert.Equal(t, "6.192", inserted.UsdExchangeRate.String())
+		assert.Equal(t, "16.15", inserted.TransactionValueUsd.String())
+	})
+
+	t.Run("Deve assumir dólar quando a moeda não for informada", func(t *testing.T) {
+		_, err := checkout.CreateTransaction("Pedido", transactionDate, decimal.NewFromInt(100), "")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "USD", inserted.Currency)
+		assert.Equal(t, "100", inserted.TransactionValueUsd.String())
+	})
+
+	t.Run("Deve retornar erro quando não houver cotação para a moeda do pedido", func(t *testing.T) {
+		_, err := checkout.CreateTransaction("Pedido", transactionDate, decimal.NewFromInt(100), "JPY")
+
+		coreErr, ok := err.(*coreErrors.CoreError)
+		assert.True(t, ok, "O erro retornado deve ser do tipo CoreError")
+		assert.Equal(t, "error.not.found.value.record", coreErr.Key)
+	})
+	t.Run("Deve recusar moeda sem código ISO 4217 no pedido", func(t *testing.T) {
+		currency.Lookup = service.FakeRateProvider{Records: []service.Record{{Country: "Afghanistan", Currency: "Afghani", CountryCurrencyDesc: "Afghanistan-Afghani"}}}.FindCurrency
+		defer func() { currency.Lookup = nil }()
+
+		_, err := checkout.CreateTransaction("Pedido", transactionDate, decimal.NewFromInt(100), "Afghanistan")
+
+		coreErr, ok := err.(*coreErrors.CoreError)
+		assert.True(t, ok, "O erro retornado deve ser do tipo CoreError")
+		assert.Equal(t, "error.currency.not.supported", coreErr.Key)
+	})
+}
+
+func TestGetByID(t *testing.T) {
+	database.DB_QUERIER = MockQuerier{
+		Transactions: map[int64]sqlc.SelectTransactionByIDRow{
+			1: {ID: 1, Description: "Pedido", TransactionDate: time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC), TransactionValue: decimal.RequireFromString("10.5"), Currency: "USD", UsdExchangeRate: decimal.RequireFromString("1"), TransactionValueUsd: decimal.RequireFromString("10.5")},
+			2: {ID: 2, Description: "Pedido em reais", TransactionDate: time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC), TransactionValue: decimal.RequireFromString("61.92"), Currency: "BRL", UsdExchangeRate: decimal.RequireFromString("6.192"), TransactionValueUsd: decimal.RequireFromString("10")},
+		},
+	}
+
+	checkout := service.Checkout{
+		RateProvider: service.FakeRateProvider{
+			Records: []service.Record{
+				{Country: "Brazil", CountryCurrencyDesc: "Brazil-Real", EffectiveDate: "2024-12-31", ExchangeRate: "6.192"},
+				{Country: "Canada", CountryCurrencyDesc: "Canada-Dollar", EffectiveDate: "2024-12-31", ExchangeRate: "1.44"},
+			},
+		},
+	}
+
+	t.Run("Deve converter o valor com a cotação do provedor", func(t *testing.T) {
+		transaction, err := checkout.GetByID(1, "brazil", nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "6.192", transaction.ExchangeRate.String())
+		assert.Equal(t, "65.02", transaction.TransactionValueConvertedToWishCurrency.String())
+	})
+
+	t.Run("Deve aceitar o código ISO 4217 da moeda", func(t *testing.T) {
+		transaction, err := checkout.GetByID(1, "BRL", nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "6.192", transaction.ExchangeRate.String())
+	})
+
+	t.Run("Deve retornar o próprio valor para dólar", func(t *testing.T) {
+		transaction, err := checkout.GetByID(1, "united states of america", nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "1", transaction.ExchangeRate.String())
+		assert.Equal(t, "10.5", transaction.TransactionValueConvertedToWishCurrency.String())
+	})
+
+	t.Run("Deve converter pedidos em outra moeda usando o dólar como pivô", func(t *testing.T) {
+		transaction, err := checkout.GetByID(2, "canada", []string{"USD", "BRL"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "0.23255814", transaction.ExchangeRate.String())
+		assert.Equal(t, "14.4", transaction.TransactionValueConvertedToWishCurrency.String())
+		assert.Equal(t, "10", transaction.Conversions[0].TransactionValueConvertedToWishCurrency.String())
+		assert.Equal(t, "1", transaction.Conversions[1].ExchangeRate.String())
+		assert.Equal(t, "61.92", transaction.Conversions[1].TransactionValueConvertedToWishCurrency.String())
+	})
+
+	t.Run("Deve retornar erro quando não houver cotação para o país", func(t *testing.T) {
+		_, err := checkout.GetByID(1, "japan", nil)
+
+		coreErr, ok := err.(*coreErrors.CoreError)
+		assert.True(t, ok, "O erro retornado deve ser do tipo CoreError")
+		assert.Equal(t, "error.not.found.value.record", coreErr.Key)
+	})
+
+	t.Run("Deve converter para várias moedas com erro individual por moeda", func(t *testing.T) {
+		transaction, err := checkout.GetByID(1, "brazil", []string{"USD", "Japan", "Atlantis"})
+
+		assert.NoError(t, err)
+		assert.Len(t, transaction.Conversions, 3)
+
+		assert.Equal(t, "USD", transaction.Conversions[0].Currency)
+		assert.Equal(t, "10.5", transaction.Conversions[0].TransactionValueConvertedToWishCurrency.String())
+		assert.Nil(t, transaction.Conversions[0].Error)
+
+		assert.Equal(t, "error.not.found.value.record", transaction.Conversions[1].Error.Key)
+		assert.Equal(t, "error.currency.not.supported", transaction.Conversions[2].Error.Key)
+	})
+
+	t.Run("Deve retornar erro para moeda não suportada", func(t *testing.T) {
+		_, err := checkout.GetByID(1, "Atlantis", nil)
+
+		coreErr, ok := err.(*coreErrors.CoreError)
+		assert.True(t, ok, "O erro retornado deve ser do tipo CoreError")
+		assert.Equal(t, "error.currency.not.supported", coreErr.Key)
+	})
+}
+
+func TestGetList(t *testing.T) {
+	database.DB_QUERIER = MockQuerier{
+		List: []sqlc.SelectTransactionsRow{
+			{ID: 1, Description: "Pedido 1", TransactionDate: time.Date(2024, 11, 5, 10, 0, 0, 0, time.UTC), TransactionValue: decimal.RequireFromString("10"), Currency: "USD", UsdExchangeRate: decimal.RequireFromString("1"), TransactionValueUsd: decimal.RequireFromString("10")},
+			{ID: 2, Description: "Pedido 2", TransactionDate: time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC), TransactionValue: decimal.RequireFromString("10"), Currency: "USD", UsdExchangeRate: decimal.RequireFromString("1"), TransactionValueUsd: decimal.RequireFromString("10")},
+			{ID: 3, Description: "Pedido 3", TransactionDate: time.Date(2025, 1, 6, 18, 0, 0, 0, time.UTC), TransactionValue: decimal.RequireFromString("20"), Currency: "USD", UsdExchangeRate: decimal.RequireFromString("1"), TransactionValueUsd: decimal.RequireFromString("20")},
+		},
+	}
+
+	calls := 0
+	checkout := service.Checkout{
+		RateProvider: CountingRateProvider{
+			FakeRateProvider: service.FakeRateProvider{
+				Records: []service.Record{
+					{CountryCurrencyDesc: "Brazil-Real", EffectiveDate: "2024-09-30", ExchangeRate: "5.434"},
+					{CountryCurrencyDesc: "Brazil-Real", EffectiveDate: "2024-12-31", ExchangeRate: "6.192"},
+				},
+			},
+			Calls: &calls,
+		},
+	}
+
+	t.Run("Deve converter cada transação com a cotação da sua própria data", func(t *testing.T) {
+		models, total, err := checkout.GetList(map[string]string{}, 10, 0, "BRL", nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), total)
+		assert.Equal(t, "5.434", models[0].ExchangeRate.String())
+		assert.Equal(t, "54.34", models[0].TransactionValueConvertedToWishCurrency.String())
+		assert.Equal(t, "6.192", models[1].ExchangeRate.String())
+		assert.Equal(t, "123.84", models[2].TransactionValueConvertedToWishCurrency.String())
+		assert.Equal(t, 2, calls, "a cotação deve ser resolvida uma vez por registro")
+	})
+
+	t.Run("Deve incluir as conversões para as moedas solicitadas em cada transação", func(t *testing.T) {
+		models, _, err := checkout.GetList(map[string]string{}, 10, 0, "BRL", []string{"USD", "JPY"})
+
+		assert.NoError(t, err)
+		for _, model := range models {
+			assert.Len(t, model.Conversions, 2)
+			assert.Equal(t, model.TransactionValue, model.Conversions[0].TransactionValueConvertedToWishCurrency)
+			assert.Equal(t, "error.not.found.value.record", model.Conversions[1].Error.Key)
+		}
+	})
+
+	t.Run("Deve resolver uma vez cada registro para pedidos de dias diferentes no mesmo período", func(t *testing.T) {
+		var list []sqlc.SelectTransactionsRow
+		for day := 0; day < 90; day++ {
+			list = append(list, sqlc.SelectTransactionsRow{ID: int64(day + 1), Description: "Pedido", TransactionDate: time.Date(2025, 1, 1+day, 12, 0, 0, 0, time.UTC), TransactionValue: decimal.RequireFromString("10"), Currency: "USD", UsdExchangeRate: decimal.RequireFromString("1"), TransactionValueUsd: decimal.RequireFromString("10")})
+		}
+		database.DB_QUERIER = MockQuerier{List: list}
+
+		for _, selection := range []service.RateSelection{service.RATE_SELECTION_ON_OR_BEFORE, service.RATE_SELECTION_STRICTLY_BEFORE} {
+			calls = 0
+			policy := service.RatePolicy{Selection: selection}
+
+			models, _, err := service.Checkout{RateProvider: checkout.RateProvider, RatePolicy: &policy}.GetList(map[string]string{}, 100, 0, "BRL", nil)
+
+			assert.NoError(t, err)
+			assert.Len(t, models, 90)
+			for _, model := range models {
+				assert.Equal(t, "2024-12-31", model.Legs.To.EffectiveDate)
+			}
+			assert.Equal(t, 1, calls, string(selection))
+		}
+
+		calls = 0
+		policy := service.RatePolicy{Selection: service.RATE_SELECTION_NEAREST}
+
+		_, _, err := service.Checkout{RateProvider: checkout.RateProvider, RatePolicy: &policy}.GetList(map[string]string{}, 100, 0, "BRL", nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 90, calls, "a política nearest pode escolher um registro posterior e é resolvida por dia")
+	})
+}
+
+func TestUpdateTransaction(t *testing.T) {
+	transactionDate := time.Date(2025, 1, 6, 10, 0, 0, 0, time.UTC)
+	transactions := map[int64]sqlc.SelectTransactionByIDRow{
+		1: {ID: 1, Description: "Pedido", TransactionDate: transactionDate, TransactionValue: decimal.RequireFromString("100"), Currency: "BRL", UsdExchangeRate: decimal.RequireFromString("6.192"), TransactionValueUsd: decimal.RequireFromString("16.15"), UsdRateEffectiveDate: "2024-12-31", Status: service.StatusPaid},
+		2: {ID: 2, Description: "Pedido", TransactionDate: transactionDate, TransactionValue: decimal.RequireFromString("61.92"), Currency: "BRL", UsdExchangeRate: decimal.RequireFromString("6.192"), TransactionValueUsd: decimal.RequireFromString("10"), UsdRateEffectiveDate: "2024-12-31", QuoteID: 7, Status: service.StatusPending},
+		3: {ID: 3, Description: "Pedido", TransactionDate: transactionDate, TransactionValue: decimal.RequireFromString("100"), Currency: "BRL", UsdExchangeRate: decimal.RequireFromString("6.192"), TransactionValueUsd: decimal.RequireFromString("16.15"), UsdRateEffectiveDate: "2024-12-31", Status: service.StatusRefunded},
+	}
+	checkout := service.Checkout{
+		RateProvider: service.FakeRateProvider{
+			Records: []service.Record{
+				{CountryCurrencyDesc: "Brazil-Real", EffectiveDate: "2024-09-30", ExchangeRate: "5.44"},
+				{CountryCurrencyDesc: "Brazil-Real", EffectiveDate: "2024-12-31", ExchangeRate: "6.192"},
+			},
+		},
+	}
+
+	newMock := func() (MockQuerier, *sqlc.UpdateTransactionParams, *[]int64) {
+		var updated sqlc.UpdateTransactionParams
+		var deleted []int64
+		return MockQuerier{Transactions: transactions, Updated: &updated, Deleted: &deleted}, &updated, &deleted
+	}
+
+	t.Run("Deve alterar somente a descrição sem descartar as conversões", func(t *testing.T) {
+		mock, updated, deleted := newMock()
+		database.DB_QUERIER = mock
+
+		description := "Pedido corrigido"
+		err := checkout.UpdateTransaction(1, service.TransactionUpdate{Description: &description})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Pedido corrigido", updated.Description)
+		assert.Equal(t, transactionDate, updated.TransactionDate)
+		assert.Equal(t, "16.15", updated.TransactionValueUsd.String())
+		assert.Empty(t, *deleted)
+	})
+
+	t.Run("Deve usar a cotação da nova data e descartar as conversões", func(t *testing.T) {
+		mock, updated, deleted := newMock()
+		database.DB_QUERIER = mock
+
+		newDate := time.Date(2024, 11, 15, 0, 0, 0, 0, time.UTC)
+		err := checkout.UpdateTransaction(1, service.TransactionUpdate{TransactionDate: &newDate})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "5.44", updated.UsdExchangeRate.String())
+		assert.Equal(t, "2024-09-30", updated.UsdRateEffectiveDate)
+		assert.Equal(t, "18.38", updated.TransactionValueUsd.String())
+		assert.Equal(t, []int64{1}, *deleted)
+	})
+
+	t.Run("Deve manter a cotação travada ao mudar a data de um pedido com cotação", func(t *testing.T) {
+		mock, updated, _ := newMock()
+		database.DB_QUERIER = mock
+
+		newDate := time.Date(2024, 11, 15, 0, 0, 0, 0, time.UTC)
+		value := decimal.RequireFromString("30.96")
+		err := checkout.UpdateTransaction(2, service.TransactionUpdate{TransactionDate: &newDate, TransactionValue: &value})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "6.192", updated.UsdExchangeRate.String())
+		assert.Equal(t, "5", updated.TransactionValueUsd.String())
+	})
+
+	t.Run("Deve validar os campos alterados", func(t *testing.T) {
+		mock, _, _ := newMock()
+		database.DB_QUERIER = mock
+
+		description := ""
+		err := checkout.UpdateTransaction(1, service.TransactionUpdate{Description: &description})
+		assert.Equal(t, "error.description.empty", err.(*coreErrors.CoreError).Key)
+
+		value := decimal.NewFromInt(-1)
+		err = checkout.UpdateTransaction(1, service.TransactionUpdate{TransactionValue: &value})
+		assert.Equal(t, "error.value.not.positive", err.(*coreErrors.CoreError).Key)
+	})
+
+	t.Run("Deve retornar erro quando o novo valor for menor que o reembolsado", func(t *testing.T) {
+		mock, _, _ := newMock()
+		mock.Refunds = map[int64][]sqlc.Refund{1: {{ID: 1, OrderID: 1, Amount: decimal.RequireFromString("40")}}}
+		database.DB_QUERIER = mock
+
+		value := decimal.RequireFromString("39.99")
+		err := checkout.UpdateTransaction(1, service.TransactionUpdate{TransactionValue: &value})
+		assert.Equal(t, "error.transaction.value.below.refunds", err.(*coreErrors.CoreError).Key)
+	})
+
+	t.Run("Deve retornar erro ao alterar o valor de um pedido com itens", func(t *testing.T) {
+		mock, _, _ := newMock()
+		mock.Items = map[int64][]sqlc.OrderItem{1: {{ID: 1, OrderID: 1, Sku: "CAM-01", Name: "Camiseta", Quantity: 1, UnitPrice: decimal.RequireFromString("100")}}}
+		database.DB_QUERIER = mock
+
+		value := decimal.RequireFromString("90")
+		err := checkout.UpdateTransaction(1, service.TransactionUpdate{TransactionValue: &value})
+		assert.Equal(t, "error.items.value.readonly", err.(*coreErrors.CoreError).Key)
+	})
+
+	t.Run("Deve retornar erro ao alterar data ou valor de pedido fora de pending ou paid", func(t *testing.T) {
+		mock, updated, _ := newMock()
+		database.DB_QUERIER = mock
+
+		value := decimal.RequireFromString("90")
+		err := checkout.UpdateTransaction(3, service.TransactionUpdate{TransactionValue: &value})
+		assert.Equal(t, "error.transaction.status.readonly", err.(*coreErrors.CoreError).Key)
+
+		newDate := time.Date(2024, 11, 15, 0, 0, 0, 0, time.UTC)
+		err = checkout.UpdateTransaction(3, service.TransactionUpdate{TransactionDate: &newDate})
+		assert.Equal(t, "error.transaction.status.readonly", err.(*coreErrors.CoreError).Key)
+		assert.Empty(t, updated.Description)
+
+		description := "Pedido reembolsado"
+		err = checkout.UpdateTransaction(3, service.TransactionUpdate{Description: &description})
+		assert.NoError(t, err)
+		assert.Equal(t, "Pedido reembolsado", updated.Description)
+	})
+
+	t.Run("Deve ler, validar e alterar o pedido dentro da transação", func(t *testing.T) {
+		mock, updated, _ := newMock()
+		database.DB_QUERIER = MockQuerier{}
+
+		transactions := 0
+		previous := database.DB_TRANSACTION
+		database.DB_TRANSACTION = func(fn func(querier sqlc.Querier) error) error {
+			transactions++
+			return fn(mock)
+		}
+		defer func() { database.DB_TRANSACTION = previous }()
+
+		value := decimal.RequireFromString("90")
+		err := checkout.UpdateTransaction(1, service.TransactionUpdate{TransactionValue: &value})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, transactions)
+		assert.Equal(t, "90", updated.TransactionValue.String())
+	})
+
+	t.Run("Deve retornar erro de não encontrado", func(t *testing.T) {
+		mock, _, _ := newMock()
+		database.DB_QUERIER = mock
+
+		err := checkout.UpdateTransaction(9, service.TransactionUpdate{})
+
+		assert.True(t, service.IsNotFound(err))
+	})
+}
+
+func TestDeleteTransaction(t *testing.T) {
+	database.DB_QUERIER = MockQuerier{
+		Transactions: map[int64]sqlc.SelectTransactionByIDRow{
+			1: {ID: 1, Description: "Pedido", TransactionValue: decimal.RequireFromString("10"), Currency: "USD", UsdExchangeRate: decimal.RequireFromString("1")},
+			2: {ID: 2, Description: "Pedido anulado", TransactionValue: decimal.RequireFromString("10"), Currency: "USD", UsdExchangeRate: decimal.RequireFromString("1"), Deleted: true},
+		},
+	}
+	checkout := service.Checkout{}
+
+	t.Run("Deve excluir e restaurar o pedido", func(t *testing.T) {
+		assert.NoError(t, checkout.DeleteTransaction(1))
+		assert.NoError(t, checkout.RestoreTransaction(2))
+	})
+
+	t.Run("Deve retornar erro quando o pedido já estiver no estado pedido", func(t *testing.T) {
+		err := checkout.DeleteTransaction(2)
+		assert.Equal(t, "error.transaction.already.deleted", err.(*coreErrors.CoreError).Key)
+
+		err = checkout.RestoreTransaction(1)
+		assert.Equal(t, "error.transaction.not.deleted", err.(*coreErrors.CoreError).Key)
+	})
+
+	t.Run("Deve retornar erro de não encontrado para pedido inexistente", func(t *testing.T) {
+		assert.True(t, service.IsNotFound(checkout.DeleteTransaction(9)))
+		assert.True(t, service.IsNotFound(checkout.RestoreTransaction(9)))
+	})
+
+	t.Run("Deve ocultar o pedido excluído salvo com include_deleted", func(t *testing.T) {
+		_, err := checkout.GetByID(2, "USD", nil)
+		assert.True(t, service.IsNotFound(err))
+
+		transaction, err := service.Checkout{IncludeDeleted: true}.GetByID(2, "USD", nil)
+		assert.NoError(t, err)
+		assert.True(t, transaction.Deleted)
+	})
+}
@@ -0,0 +1,395 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/luancpereira/APICheckout/core/config"
+	"github.com/luancpereira/APICheckout/core/currency"
+	"github.com/luancpereira/APICheckout/core/database"
+	"github.com/luancpereira/APICheckout/core/database/sqlc"
+	coreError "github.com/luancpereira/APICheckout/core/errors"
+	"github.com/luancpereira/APICheckout/core/money"
+	"github.com/shopspring/decimal"
+)
+
+// defaultRateStaleAfterDays is one Treasury quarter, the interval between two
+// regular publications of the rates.
+const defaultRateStaleAfterDays = 92
+
+// crossRateSignificantDigits is the precision of the rates between two
+// currencies other than USD.
+const crossRateSignificantDigits = 8
+
+// rateStaleAfterDays is the age above which a rate is stale, set at startup by
+// LoadRateStaleAfterDays.
+var rateStaleAfterDays = defaultRateStaleAfterDays
+
+/*****
+funcs for conversions
+******/
+
+// convert converts the order to the target currency. A conversion that used
+// a Treasury record is served from its snapshot in the conversion table, so
+// later revisions of the rate do not change it, unless Refresh is set, in
+// which case it is computed again and the snapshot replaced. Conversions with
+// a RatePolicy given in the request are neither served from nor stored in the
+// snapshot, which holds the rate chosen by the deployment policy.
+func (c Checkout) convert(order orderAmount, target *conversionTarget, snapshots map[conversionKey]sqlc.SelectConversionsRow) (exchangeRate, converted decimal.Decimal, legs RateLegs, err error) {
+	if target.err != nil {
+		err = target.err
+		return
+	}
+
+	legs.From = order.leg()
+
+	if target.currency.Code == order.currency {
+		legs.To = legs.From
+		return decimal.NewFromInt(1), order.value, legs, nil
+	}
+
+	if snapshot, found := snapshots[conversionKey{order.id, target.currency.Code}]; found && !c.Refresh {
+		legs.To = newRateLeg(target.currency, target.currency.Key, snapshot.ExchangeRate, snapshot.EffectiveDate, order.transactionDate)
+		return snapshot.ConvertedExchangeRate, snapshot.ConvertedValue, legs, nil
+	}
+
+	result := c.getConversionRate(target, order.transactionDate)
+	if result.err != nil {
+		err = result.err
+		return
+	}
+
+	legs.To = result.leg(order.transactionDate)
+	exchangeRate, converted = result.convert(order)
+
+	err = c.saveConversionSnapshot(order, result, exchangeRate, converted)
+
+	return
+}
+
+func (c Checkout) newConversion(requested string, order orderAmount, target *conversionTarget, snapshots map[conversionKey]sqlc.SelectConversionsRow) (conversion Conversion) {
+	conversion.Currency = requested
+
+	exchangeRate, converted, legs, err := c.convert(order, target, snapshots)
+	if err != nil {
+		conversion.Error = coreError.ConvertTo(err)
+		return
+	}
+
+	conversion.ExchangeRate = exchangeRate
+	conversion.Legs = &legs
+	conversion.Stale = legs.stale()
+	conversion.TransactionValueConvertedToWishCurrency = converted
+
+	return
+}
+
+// getConversionRate resolves the rate of the target currency once per rate
+// record: the days the policy is known to resolve to a record already looked
+// up reuse it, so every order is converted at the rate that applied on its own
+// date without a lookup per day.
+func (c Checkout) getConversionRate(target *conversionTarget, transactionDate time.Time) exchangeRateResult {
+	day := transactionDate.Format("2006-01-02")
+	if result, resolved := target.rates[day]; resolved {
+		return result
+	}
+
+	for _, period := range target.periods {
+		if period.from <= day && day <= period.to {
+			target.rates[day] = period.result
+			return period.result
+		}
+	}
+
+	result := exchangeRateResult{target: target.currency}
+	result.rate, result.record, result.err = c.getExchangeRate(transactionDate, target.currency)
+	target.rates[day] = result
+
+	if result.err == nil && coreError.StringIsNotEmpty(result.record.EffectiveDate) {
+		from, found := c.ratePolicy().firstDayOf(result.record.EffectiveDate)
+		if found {
+			target.periods = append(target.periods, ratePeriod{from: from, to: day, result: result})
+		}
+	}
+
+	return result
+}
+
+// resolveConversionRates resolves the rates of the targets for the orders from
+// the latest transaction date to the earliest, so each record is looked up for
+// the latest day of its period and reused for the earlier ones.
+func (c Checkout) resolveConversionRates(orders []orderAmount, targets []*conversionTarget, snapshots map[conversionKey]sqlc.SelectConversionsRow) {
+	sorted := append([]orderAmount(nil), orders...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].transactionDate.After(sorted[j].transactionDate)
+	})
+
+	for _, target := range targets {
+		if target.err != nil {
+			continue
+		}
+
+		for _, order := range sorted {
+			if target.currency.Code == order.currency {
+				continue
+			}
+
+			if _, found := snapshots[conversionKey{order.id, target.currency.Code}]; found && !c.Refresh {
+				continue
+			}
+
+			c.getConversionRate(target, order.transactionDate)
+		}
+	}
+}
+
+// getConversionSnapshots loads the stored conversions of the orders to the
+// targets that were resolved, none when the request overrides the policy.
+func (c Checkout) getConversionSnapshots(orderIDs []int64, targets []*conversionTarget) (snapshots map[conversionKey]sqlc.SelectConversionsRow, err error) {
+	snapshots = make(map[conversionKey]sqlc.SelectConversionsRow)
+
+	var codes []string
+	for _, target := range targets {
+		if target.err == nil && coreError.StringIsNotEmpty(target.currency.Code) {
+			codes = append(codes, target.currency.Code)
+		}
+	}
+
+	if len(orderIDs) == 0 || len(codes) == 0 || c.RatePolicy != nil {
+		return
+	}
+
+	params := sqlc.SelectConversionsParams{
+		OrderIds:   orderIDs,
+		Currencies: codes,
+	}
+
+	rows, err := database.DB_QUERIER.SelectConversions(context.Background(), params)
+	if err != nil {
+		err = database.Utils{}.CoreErrorDatabase(err)
+		return
+	}
+
+	for _, row := range rows {
+		snapshots[conversionKey{row.OrderID, row.Currency}] = row
+	}
+
+	return
+}
+
+// saveConversionSnapshot stores the conversion when it used a Treasury record.
+// Conversions to USD depend only on the rate stored with the order and are
+// not stored, nor are conversions to currencies outside the registry, which
+// have no code to key the snapshot by, or those with a policy of the request.
+func (c Checkout) saveConversionSnapshot(order orderAmount, result exchangeRateResult, exchangeRate, converted decimal.Decimal) (err error) {
+	if result.record.EffectiveDate == "" || result.target.Code == "" || c.RatePolicy != nil {
+		return
+	}
+
+	effectiveDate, err := time.Parse("2006-01-02", result.record.EffectiveDate)
+	if err != nil {
+		err = coreError.New("error.not.found.value.record")
+		return
+	}
+
+	params := sqlc.UpsertConversionParams{
+		OrderID:               order.id,
+		Currency:              result.target.Code,
+		ExchangeRate:          result.rate,
+		EffectiveDate:         effectiveDate,
+		RecordDate:            result.record.RecordDate,
+		ConvertedExchangeRate: exchangeRate,
+		ConvertedValue:        converted,
+		Refresh:               c.Refresh,
+	}
+
+	err = database.DB_QUERIER.UpsertConversion(context.Background(), params)
+	if err != nil {
+		err = database.Utils{}.CoreErrorDatabase(err)
+		return
+	}
+
+	return
+}
+
+// convert converts the order to the target currency with USD as the pivot and
+// returns the rate from the order currency to the target, with the precision
+// of crossExchangeRate, and the converted value, rounded half-even to two
+// decimals.
+func (r exchangeRateResult) convert(order orderAmount) (exchangeRate, converted decimal.Decimal) {
+	exchangeRate = crossExchangeRate(order.usdExchangeRate, r.rate)
+	converted = money.RoundMoney(order.value.Mul(r.rate).Div(order.usdExchangeRate), money.HalfEven)
+
+	return
+}
+
+func (r exchangeRateResult) leg(transactionDate time.Time) RateLeg {
+	countryCurrencyDesc := r.record.CountryCurrencyDesc
+	if !coreError.StringIsNotEmpty(countryCurrencyDesc) {
+		countryCurrencyDesc = r.target.Key
+	}
+
+	return newRateLeg(r.target, countryCurrencyDesc, r.rate, r.record.EffectiveDate, transactionDate)
+}
+
+func (o orderAmount) leg() RateLeg {
+	orderCurrency, err := currency.Resolve(o.currency)
+	if err != nil {
+		orderCurrency = currency.Currency{Code: o.currency}
+	}
+
+	return newRateLeg(orderCurrency, orderCurrency.Key, o.usdExchangeRate, o.usdRateEffectiveDate, o.transactionDate)
+}
+
+// newRateLeg builds the leg with the age of the rate, the distance in days
+// between its effective date and the transaction date, and flags it as stale
+// when the age exceeds RATE_STALE_AFTER_DAYS. Legs without a Treasury record,
+// USD or orders created before the date was stored, have no age.
+func newRateLeg(target currency.Currency, countryCurrencyDesc string, exchangeRate decimal.Decimal, effectiveDate string, transactionDate time.Time) (leg RateLeg) {
+	leg = RateLeg{
+		Currency:            target.Code,
+		CountryCurrencyDesc: countryCurrencyDesc,
+		ExchangeRate:        exchangeRate,
+		EffectiveDate:       effectiveDate,
+	}
+
+	recordDate, err := time.Parse("2006-01-02", effectiveDate)
+	if err != nil {
+		return
+	}
+
+	leg.AgeDays = int(truncateToDay(transactionDate).Sub(recordDate).Hours() / 24)
+	if leg.AgeDays < 0 {
+		leg.AgeDays = -leg.AgeDays
+	}
+
+	leg.Stale = rateStaleAfterDays > 0 && leg.AgeDays > rateStaleAfterDays
+
+	return
+}
+
+// convert converts value from the source to the target currency of the legs,
+// rounded half-even to two decimals.
+func (l RateLegs) convert(value decimal.Decimal) decimal.Decimal {
+	return money.RoundMoney(value.Mul(l.To.ExchangeRate).Div(l.From.ExchangeRate), money.HalfEven)
+}
+
+// stale tells whether any of the rates of the conversion is stale.
+func (l RateLegs) stale() bool {
+	return l.From.Stale || l.To.Stale
+}
+
+// LoadRateStaleAfterDays reads RATE_STALE_AFTER_DAYS once at startup, which
+// fails when it is not a number of days.
+func LoadRateStaleAfterDays() (err error) {
+	days := defaultRateStaleAfterDays
+
+	if coreError.StringIsNotEmpty(config.RATE_STALE_AFTER_DAYS) {
+		days, err = strconv.Atoi(strings.TrimSpace(config.RATE_STALE_AFTER_DAYS))
+		if err != nil || days < 0 {
+			err = coreError.New("error.config.invalid", coreError.ConcatenateStrings("RATE_STALE_AFTER_DAYS=", config.RATE_STALE_AFTER_DAYS))
+			return
+		}
+	}
+
+	rateStaleAfterDays = days
+
+	return
+}
+
+// crossExchangeRate derives the rate from one currency to another from the
+// rates of both per USD, rounded half-even to crossRateSignificantDigits so
+// small rates, such as ARS to BRL, keep their precision. Converted amounts are
+// computed from the rates per USD, not from this rate.
+func crossExchangeRate(fromUSDRate, toUSDRate decimal.Decimal) decimal.Decimal {
+	return money.RoundSignificant(toUSDRate.Div(fromUSDRate), crossRateSignificantDigits, money.HalfEven)
+}
+
+func newConversionTarget(target currency.Currency, err error) *conversionTarget {
+	return &conversionTarget{currency: target, err: err, rates: make(map[string]exchangeRateResult)}
+}
+
+func resolveConversionTargets(currencies []string) (targets []*conversionTarget) {
+	for _, requested := range currencies {
+		targets = append(targets, newConversionTarget(currency.Resolve(requested)))
+	}
+
+	return
+}
+
+/*****
+funcs for conversions
+******/
+
+/*****
+other funcs
+******/
+
+// conversionTarget is a currency the orders are converted to, with its rates
+// already resolved keyed by transaction day in the 2006-01-02 layout and the
+// periods of the records found.
+type conversionTarget struct {
+	currency currency.Currency
+	err      error
+	rates    map[string]exchangeRateResult
+	periods  []ratePeriod
+}
+
+// ratePeriod is a range of days, in the 2006-01-02 layout, that the policy
+// resolves to the record of result.
+type ratePeriod struct {
+	from   string
+	to     string
+	result exchangeRateResult
+}
+
+type conversionKey struct {
+	orderID  int64
+	currency string
+}
+
+// exchangeRateResult is the rate of target per USD on a transaction date and
+// the Treasury record it comes from.
+type exchangeRateResult struct {
+	target currency.Currency
+	rate   decimal.Decimal
+	record Record
+	err    error
+}
+
+// orderAmount is the value of an order in its own currency and the rate of
+// that currency per USD when the order was created.
+type orderAmount struct {
+	id                   int64
+	transactionDate      time.Time
+	currency             string
+	value                decimal.Decimal
+	usdExchangeRate      decimal.Decimal
+	usdRateEffectiveDate string
+}
+
+// RateLeg is one side of a conversion through the USD pivot: the rate of
+// Currency per USD and the effective date of the Treasury record it comes
+// from, empty for USD and for orders created before the date was stored.
+type RateLeg struct {
+	Currency            string
+	CountryCurrencyDesc string
+	ExchangeRate        decimal.Decimal
+	EffectiveDate       string
+	AgeDays             int
+	Stale               bool
+}
+
+// RateLegs are the two rates a conversion is derived from, the rate of the
+// target per unit of the source being To.ExchangeRate / From.ExchangeRate.
+type RateLegs struct {
+	From RateLeg
+	To   RateLeg
+}
+
+/*****
+other funcs
+******/
@@ -0,0 +1,192 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/luancpereira/APICheckout/core/config"
+	"github.com/luancpereira/APICheckout/core/currency"
+	"github.com/luancpereira/APICheckout/core/database"
+	"github.com/luancpereira/APICheckout/core/database/sqlc"
+	coreErrors "github.com/luancpereira/APICheckout/core/errors"
+	"github.com/luancpereira/APICheckout/core/service"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConversionSnapshot(t *testing.T) {
+	transactions := map[int64]sqlc.SelectTransactionByIDRow{
+		1: {ID: 1, Description: "Pedido", TransactionDate: time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC), TransactionValue: decimal.RequireFromString("10"), Currency: "USD", UsdExchangeRate: decimal.RequireFromString("1"), TransactionValueUsd: decimal.RequireFromString("10")},
+	}
+	records := []service.Record{
+		{CountryCurrencyDesc: "Brazil-Real", RecordDate: "2024-12-31", EffectiveDate: "2024-12-31", ExchangeRate: "6.192"},
+	}
+
+	t.Run("Deve guardar a conversão na primeira consulta", func(t *testing.T) {
+		var saved []sqlc.UpsertConversionParams
+		database.DB_QUERIER = MockQuerier{Transactions: transactions, Saved: &saved}
+
+		transaction, err := service.Checkout{RateProvider: service.FakeRateProvider{Records: records}}.GetByID(1, "BRL", []string{"USD"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "61.92", transaction.TransactionValueConvertedToWishCurrency.String())
+		assert.Equal(t, "USD", transaction.Legs.From.Currency)
+		assert.Equal(t, "BRL", transaction.Legs.To.Currency)
+		assert.Equal(t, "2024-12-31", transaction.Legs.To.EffectiveDate)
+		assert.Equal(t, "Brazil-Real", transaction.Legs.To.CountryCurrencyDesc)
+		assert.Equal(t, 6, transaction.Legs.To.AgeDays)
+		assert.False(t, transaction.Stale)
+		assert.Len(t, saved, 1, "a conversão para dólar não usa cotação do Tesouro e não deve ser guardada")
+		assert.Equal(t, "BRL", saved[0].Currency)
+		assert.Equal(t, "6.192", saved[0].ExchangeRate.String())
+		assert.Equal(t, time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC), saved[0].EffectiveDate)
+		assert.Equal(t, "2024-12-31", saved[0].RecordDate)
+		assert.False(t, saved[0].Refresh)
+	})
+
+	snapshots := map[int64][]sqlc.SelectConversionsRow{
+		1: {{OrderID: 1, Currency: "BRL", ExchangeRate: decimal.RequireFromString("6"), EffectiveDate: "2024-12-31", ConvertedExchangeRate: decimal.RequireFromString("6"), ConvertedValue: decimal.RequireFromString("60")}},
+	}
+
+	t.Run("Deve retornar a conversão guardada mesmo que a cotação mude", func(t *testing.T) {
+		var saved []sqlc.UpsertConversionParams
+		database.DB_QUERIER = MockQuerier{Transactions: transactions, Snapshots: snapshots, Saved: &saved}
+
+		calls := 0
+		checkout := service.Checkout{RateProvider: CountingRateProvider{service.FakeRateProvider{Records: records}, &calls}}
+
+		transaction, err := checkout.GetByID(1, "BRL", nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "6", transaction.ExchangeRate.String())
+		assert.Equal(t, "60", transaction.TransactionValueConvertedToWishCurrency.String())
+		assert.Equal(t, "2024-12-31", transaction.Legs.To.EffectiveDate)
+		assert.Equal(t, 6, transaction.Legs.To.AgeDays)
+		assert.Equal(t, 0, calls)
+		assert.Empty(t, saved)
+	})
+
+	t.Run("Deve recalcular e substituir a conversão com refresh", func(t *testing.T) {
+		var saved []sqlc.UpsertConversionParams
+		database.DB_QUERIER = MockQuerier{Transactions: transactions, Snapshots: snapshots, Saved: &saved}
+
+		checkout := service.Checkout{RateProvider: service.FakeRateProvider{Records: records}, Refresh: true}
+
+		transaction, err := checkout.GetByID(1, "BRL", nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "61.92", transaction.TransactionValueConvertedToWishCurrency.String())
+		assert.Len(t, saved, 1)
+		assert.True(t, saved[0].Refresh)
+	})
+
+	t.Run("Deve ignorar a conversão guardada quando a consulta define a política", func(t *testing.T) {
+		var saved []sqlc.UpsertConversionParams
+		database.DB_QUERIER = MockQuerier{Transactions: transactions, Snapshots: snapshots, Saved: &saved}
+
+		provider := service.FakeRateProvider{Records: append(records, service.Record{CountryCurrencyDesc: "Brazil-Real", RecordDate: "2025-01-08", EffectiveDate: "2025-01-08", ExchangeRate: "6.1"})}
+
+		onOrBefore, err := service.Checkout{RateProvider: provider, RatePolicy: &service.RatePolicy{Selection: service.RATE_SELECTION_ON_OR_BEFORE}}.GetByID(1, "BRL", nil)
+		assert.NoError(t, err)
+
+		nearest, err := service.Checkout{RateProvider: provider, RatePolicy: &service.RatePolicy{Selection: service.RATE_SELECTION_NEAREST}}.GetByID(1, "BRL", nil)
+		assert.NoError(t, err)
+
+		assert.Equal(t, "61.92", onOrBefore.TransactionValueConvertedToWishCurrency.String())
+		assert.Equal(t, "2024-12-31", onOrBefore.Legs.To.EffectiveDate)
+		assert.Equal(t, "61", nearest.TransactionValueConvertedToWishCurrency.String())
+		assert.Equal(t, "2025-01-08", nearest.Legs.To.EffectiveDate)
+		assert.Empty(t, saved, "a conversão guardada é a da política padrão")
+	})
+}
+
+func TestConversionOutsideRegistry(t *testing.T) {
+	transactions := map[int64]sqlc.SelectTransactionByIDRow{
+		1: {ID: 1, Description: "Pedido", TransactionDate: time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC), TransactionValue: decimal.RequireFromString("10"), Currency: "USD", UsdExchangeRate: decimal.RequireFromString("1"), TransactionValueUsd: decimal.RequireFromString("10")},
+	}
+	provider := service.FakeRateProvider{
+		Records: []service.Record{
+			{Country: "Afghanistan", Currency: "Afghani", CountryCurrencyDesc: "Afghanistan-Afghani", RecordDate: "2024-12-31", EffectiveDate: "2024-12-31", ExchangeRate: "70.5"},
+		},
+	}
+
+	currency.Lookup = provider.FindCurrency
+	defer func() { currency.Lookup = nil }()
+
+	var saved []sqlc.UpsertConversionParams
+	database.DB_QUERIER = MockQuerier{Transactions: transactions, Saved: &saved}
+
+	transaction, err := service.Checkout{RateProvider: provider}.GetByID(1, "afghanistan", nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "705", transaction.TransactionValueConvertedToWishCurrency.String())
+	assert.Equal(t, "Afghanistan-Afghani", transaction.Legs.To.CountryCurrencyDesc)
+	assert.Empty(t, saved, "moedas fora do registro não têm código para guardar a conversão")
+}
+
+func TestConversionStaleness(t *testing.T) {
+	transactions := map[int64]sqlc.SelectTransactionByIDRow{
+		1: {ID: 1, Description: "Pedido", TransactionDate: time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC), TransactionValue: decimal.RequireFromString("100"), Currency: "BRL", UsdExchangeRate: decimal.RequireFromString("6.192"), TransactionValueUsd: decimal.RequireFromString("16.15"), UsdRateEffectiveDate: "2024-12-31"},
+	}
+	records := []service.Record{
+		{CountryCurrencyDesc: "Mexico-Peso", RecordDate: "2024-07-31", EffectiveDate: "2024-07-31", ExchangeRate: "18.375"},
+	}
+
+	var saved []sqlc.UpsertConversionParams
+	database.DB_QUERIER = MockQuerier{Transactions: transactions, Saved: &saved}
+
+	transaction, err := service.Checkout{RateProvider: service.FakeRateProvider{Records: records}}.GetByID(1, "MXN", []string{"USD"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "BRL", transaction.Legs.From.Currency)
+	assert.Equal(t, "Brazil-Real", transaction.Legs.From.CountryCurrencyDesc)
+	assert.Equal(t, 6, transaction.Legs.From.AgeDays)
+	assert.False(t, transaction.Legs.From.Stale)
+	assert.Equal(t, "Mexico-Peso", transaction.Legs.To.CountryCurrencyDesc)
+	assert.Equal(t, 159, transaction.Legs.To.AgeDays)
+	assert.True(t, transaction.Legs.To.Stale, "a cotação tem mais de 92 dias")
+	assert.True(t, transaction.Stale)
+
+	assert.Len(t, transaction.Conversions, 1)
+	assert.Equal(t, "United States-Dollar", transaction.Conversions[0].Legs.To.CountryCurrencyDesc)
+	assert.Equal(t, 0, transaction.Conversions[0].Legs.To.AgeDays)
+	assert.False(t, transaction.Conversions[0].Stale)
+}
+
+func TestLoadRateStaleAfterDays(t *testing.T) {
+	defer func() {
+		config.RATE_STALE_AFTER_DAYS = ""
+		service.LoadRateStaleAfterDays()
+	}()
+
+	transactions := map[int64]sqlc.SelectTransactionByIDRow{
+		1: {ID: 1, Description: "Pedido", TransactionDate: time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC), TransactionValue: decimal.RequireFromString("10"), Currency: "USD", UsdExchangeRate: decimal.RequireFromString("1"), TransactionValueUsd: decimal.RequireFromString("10")},
+	}
+	records := []service.Record{
+		{CountryCurrencyDesc: "Brazil-Real", RecordDate: "2024-12-31", EffectiveDate: "2024-12-31", ExchangeRate: "6.192"},
+	}
+
+	t.Run("Deve usar o limite configurado", func(t *testing.T) {
+		database.DB_QUERIER = MockQuerier{Transactions: transactions}
+		config.RATE_STALE_AFTER_DAYS = "5"
+
+		assert.NoError(t, service.LoadRateStaleAfterDays())
+
+		transaction, err := service.Checkout{RateProvider: service.FakeRateProvider{Records: records}}.GetByID(1, "BRL", nil)
+
+		assert.NoError(t, err)
+		assert.True(t, transaction.Legs.To.Stale, "a cotação tem 6 dias")
+	})
+
+	t.Run("Deve retornar erro para configuração inválida", func(t *testing.T) {
+		for _, value := range []string{"abc", "-1"} {
+			config.RATE_STALE_AFTER_DAYS = value
+
+			err := service.LoadRateStaleAfterDays()
+
+			coreErr, ok := err.(*coreErrors.CoreError)
+			assert.True(t, ok, "O erro retornado deve ser do tipo CoreError")
+			assert.Equal(t, "error.config.invalid", coreErr.Key)
+		}
+	})
+}
@@ -0,0 +1,123 @@
+package service
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/luancpereira/APICheckout/core/config"
+	"github.com/luancpereira/APICheckout/core/currency"
+	coreError "github.com/luancpereira/APICheckout/core/errors"
+	"github.com/luancpereira/APICheckout/core/money"
+	"github.com/shopspring/decimal"
+)
+
+const defaultConvertBatchMax = 100
+
+// ConvertItem is an amount to convert without a stored order. Date is in the
+// 2006-01-02 layout, today when empty, and From defaults to USD.
+type ConvertItem struct {
+	Amount   decimal.Decimal
+	Date     string
+	From     string
+	Currency string
+}
+
+// ConvertedAmount is an amount converted with the rates that applied on Date.
+// In a batch, an item that cannot be converted carries its own Error.
+type ConvertedAmount struct {
+	Date            string
+	Amount          decimal.Decimal
+	From            string
+	Currency        string
+	ExchangeRate    decimal.Decimal
+	ConvertedAmount decimal.Decimal
+	Legs            RateLegs
+	Stale           bool
+	Error           *coreError.CoreError
+}
+
+/*****
+funcs for conversions
+******/
+
+// Convert converts the amount with the same rate lookup and rounding as the
+// conversions of the orders: the amount is rounded half-up to cents, the
+// converted amount and the rate half-even.
+func (c Checkout) Convert(item ConvertItem) (converted ConvertedAmount, err error) {
+	err = c.ValidateTrasactionValue(item.Amount)
+	if err != nil {
+		return
+	}
+
+	date := time.Now()
+	if coreError.StringIsNotEmpty(item.Date) {
+		date, err = time.Parse("2006-01-02", item.Date)
+		if err != nil {
+			err = coreError.New("error.convert.date.invalid", item.Date)
+			return
+		}
+	}
+
+	if !coreError.StringIsNotEmpty(item.From) {
+		item.From = currency.USD
+	}
+
+	crossRate, err := c.GetCrossRate(item.From, item.Currency, date)
+	if err != nil {
+		return
+	}
+
+	amount := orderAmount{
+		transactionDate: date,
+		currency:        crossRate.Legs.From.Currency,
+		value:           money.RoundMoney(item.Amount, money.HalfUp),
+		usdExchangeRate: crossRate.Legs.From.ExchangeRate,
+	}
+
+	exchangeRate, value := exchangeRateResult{rate: crossRate.Legs.To.ExchangeRate}.convert(amount)
+
+	converted = ConvertedAmount{
+		Date:            crossRate.Date,
+		Amount:          amount.value,
+		From:            crossRate.Legs.From.Currency,
+		Currency:        crossRate.Legs.To.Currency,
+		ExchangeRate:    exchangeRate,
+		ConvertedAmount: value,
+		Legs:            crossRate.Legs,
+		Stale:           crossRate.Stale,
+	}
+
+	return
+}
+
+// ConvertBatch converts up to CONVERT_BATCH_MAX items (default 100). Items
+// that cannot be converted are returned with their error instead of failing
+// the whole batch.
+func (c Checkout) ConvertBatch(items []ConvertItem) (results []ConvertedAmount, err error) {
+	batchMax := intFromConfig("CONVERT_BATCH_MAX", config.CONVERT_BATCH_MAX, defaultConvertBatchMax)
+	if len(items) == 0 || len(items) > batchMax {
+		err = coreError.New("error.convert.batch.size", strconv.Itoa(batchMax))
+		return
+	}
+
+	for _, item := range items {
+		converted, errConvert := c.Convert(item)
+		if errConvert != nil {
+			converted = ConvertedAmount{
+				Date:     item.Date,
+				Amount:   item.Amount,
+				From:     item.From,
+				Currency: item.Currency,
+				Error:    coreError.ConvertTo(errConvert),
+			}
+		}
+
+		results = append(results, converted)
+	}
+
+	return
+}
+
+/*****
+funcs for conversions
+******/
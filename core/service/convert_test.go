@@ -0,0 +1,73 @@
+package service_test
+
+import (
+	"testing"
+
+	coreErrors "github.com/luancpereira/APICheckout/core/errors"
+	"github.com/luancpereira/APICheckout/core/service"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvert(t *testing.T) {
+	checkout := service.Checkout{
+		RateProvider: service.FakeRateProvider{
+			Records: []service.Record{
+				{CountryCurrencyDesc: "Mexico-Peso", EffectiveDate: "2023-12-31", ExchangeRate: "16.92"},
+				{CountryCurrencyDesc: "Brazil-Real", EffectiveDate: "2023-12-31", ExchangeRate: "4.85"},
+			},
+		},
+	}
+
+	t.Run("Deve converter o valor em dólar para a moeda do país", func(t *testing.T) {
+		converted, err := checkout.Convert(service.ConvertItem{Amount: decimal.RequireFromString("1250.00"), Date: "2024-03-15", Currency: "Mexico"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "2024-03-15", converted.Date)
+		assert.Equal(t, "USD", converted.From)
+		assert.Equal(t, "MXN", converted.Currency)
+		assert.Equal(t, "16.92", converted.ExchangeRate.String())
+		assert.Equal(t, "21150", converted.ConvertedAmount.String())
+		assert.Equal(t, "2023-12-31", converted.Legs.To.EffectiveDate)
+		assert.Equal(t, 75, converted.Legs.To.AgeDays)
+	})
+
+	t.Run("Deve converter entre duas moedas diferentes do dólar", func(t *testing.T) {
+		converted, err := checkout.Convert(service.ConvertItem{Amount: decimal.RequireFromString("100"), Date: "2024-03-15", From: "BRL", Currency: "MXN"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "3.4886598", converted.ExchangeRate.String())
+		assert.Equal(t, "348.87", converted.ConvertedAmount.String())
+	})
+
+	t.Run("Deve retornar erro para data inválida", func(t *testing.T) {
+		_, err := checkout.Convert(service.ConvertItem{Amount: decimal.NewFromInt(1), Date: "15/03/2024", Currency: "MXN"})
+
+		coreErr, ok := err.(*coreErrors.CoreError)
+		assert.True(t, ok, "O erro retornado deve ser do tipo CoreError")
+		assert.Equal(t, "error.convert.date.invalid", coreErr.Key)
+	})
+
+	t.Run("Deve retornar o erro de cada item no lote", func(t *testing.T) {
+		results, err := checkout.ConvertBatch([]service.ConvertItem{
+			{Amount: decimal.NewFromInt(10), Date: "2024-03-15", Currency: "MXN"},
+			{Amount: decimal.NewFromInt(10), Date: "2024-03-15", Currency: "JPY"},
+			{Amount: decimal.NewFromInt(-1), Date: "2024-03-15", Currency: "MXN"},
+		})
+
+		assert.NoError(t, err)
+		assert.Len(t, results, 3)
+		assert.Nil(t, results[0].Error)
+		assert.Equal(t, "169.2", results[0].ConvertedAmount.String())
+		assert.Equal(t, "error.not.found.value.record", results[1].Error.Key)
+		assert.Equal(t, "error.value.not.positive", results[2].Error.Key)
+	})
+
+	t.Run("Deve rejeitar lote vazio ou acima do limite", func(t *testing.T) {
+		_, err := checkout.ConvertBatch(nil)
+		assert.Equal(t, "error.convert.batch.size", err.(*coreErrors.CoreError).Key)
+
+		_, err = checkout.ConvertBatch(make([]service.ConvertItem, 101))
+		assert.Equal(t, "error.convert.batch.size", err.(*coreErrors.CoreError).Key)
+	})
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"time"
+
+	"github.com/luancpereira/APICheckout/core/currency"
+	"github.com/shopspring/decimal"
+)
+
+// CrossRate is the rate from one currency to another on a date, derived
+// through the USD pivot from the records that apply to each of them.
+type CrossRate struct {
+	Date         string
+	ExchangeRate decimal.Decimal
+	Legs         RateLegs
+	Stale        bool
+}
+
+/*****
+funcs for gets
+******/
+
+// GetCrossRate resolves the rates of both currencies per USD on date under
+// the rate policy and derives the rate from one to the other, e.g. BRL to
+// ARS, with the precision of crossExchangeRate.
+func (c Checkout) GetCrossRate(from, to string, date time.Time) (crossRate CrossRate, err error) {
+	fromCurrency, err := currency.Resolve(from)
+	if err != nil {
+		return
+	}
+
+	toCurrency, err := currency.Resolve(to)
+	if err != nil {
+		return
+	}
+
+	fromLeg, err := c.getRateLeg(date, fromCurrency)
+	if err != nil {
+		return
+	}
+
+	toLeg, err := c.getRateLeg(date, toCurrency)
+	if err != nil {
+		return
+	}
+
+	legs := RateLegs{From: fromLeg, To: toLeg}
+
+	crossRate = CrossRate{
+		Date:         date.Format("2006-01-02"),
+		ExchangeRate: crossExchangeRate(fromLeg.ExchangeRate, toLeg.ExchangeRate),
+		Legs:         legs,
+		Stale:        legs.stale(),
+	}
+
+	return
+}
+
+func (c Checkout) getRateLeg(date time.Time, target currency.Currency) (leg RateLeg, err error) {
+	exchangeRate, record, err := c.getExchangeRate(date, target)
+	if err != nil {
+		return
+	}
+
+	leg = exchangeRateResult{target: target, rate: exchangeRate, record: record}.leg(date)
+
+	return
+}
+
+/*****
+funcs for gets
+******/
@@ -0,0 +1,64 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	coreErrors "github.com/luancpereira/APICheckout/core/errors"
+	"github.com/luancpereira/APICheckout/core/service"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetCrossRate(t *testing.T) {
+	checkout := service.Checkout{
+		RateProvider: service.FakeRateProvider{
+			Records: []service.Record{
+				{CountryCurrencyDesc: "Brazil-Real", EffectiveDate: "2024-12-31", ExchangeRate: "6.192"},
+				{CountryCurrencyDesc: "Argentina-Peso", EffectiveDate: "2024-12-31", ExchangeRate: "1031.0"},
+				{CountryCurrencyDesc: "Euro Zone-Euro", EffectiveDate: "2024-09-30", ExchangeRate: "0.897"},
+			},
+		},
+	}
+	date := time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Deve derivar a cotação cruzada pelo dólar com as duas pernas", func(t *testing.T) {
+		crossRate, err := checkout.GetCrossRate("BRL", "ARS", date)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "2025-01-06", crossRate.Date)
+		assert.Equal(t, "166.50517", crossRate.ExchangeRate.String())
+		assert.Equal(t, service.RateLeg{Currency: "BRL", CountryCurrencyDesc: "Brazil-Real", ExchangeRate: crossRate.Legs.From.ExchangeRate, EffectiveDate: "2024-12-31", AgeDays: 6}, crossRate.Legs.From)
+		assert.Equal(t, "6.192", crossRate.Legs.From.ExchangeRate.String())
+		assert.Equal(t, "ARS", crossRate.Legs.To.Currency)
+		assert.Equal(t, "1031", crossRate.Legs.To.ExchangeRate.String())
+		assert.Equal(t, "2024-12-31", crossRate.Legs.To.EffectiveDate)
+	})
+
+	t.Run("Deve manter a precisão de cotações cruzadas menores que um centavo", func(t *testing.T) {
+		crossRate, err := checkout.GetCrossRate("ARS", "BRL", date)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "0.0060058196", crossRate.ExchangeRate.String())
+	})
+
+	t.Run("Deve informar a data de cada perna quando forem diferentes", func(t *testing.T) {
+		crossRate, err := checkout.GetCrossRate("EUR", "BRL", date)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "6.90", crossRate.ExchangeRate.StringFixed(2))
+		assert.Equal(t, "2024-09-30", crossRate.Legs.From.EffectiveDate)
+		assert.Equal(t, "2024-12-31", crossRate.Legs.To.EffectiveDate)
+		assert.Equal(t, 98, crossRate.Legs.From.AgeDays)
+		assert.True(t, crossRate.Legs.From.Stale)
+		assert.False(t, crossRate.Legs.To.Stale)
+		assert.True(t, crossRate.Stale)
+	})
+
+	t.Run("Deve retornar erro quando uma das moedas não tiver cotação", func(t *testing.T) {
+		_, err := checkout.GetCrossRate("BRL", "JPY", date)
+
+		coreErr, ok := err.(*coreErrors.CoreError)
+		assert.True(t, ok, "O erro retornado deve ser do tipo CoreError")
+		assert.Equal(t, "error.not.found.value.record", coreErr.Key)
+	})
+}
@@ -0,0 +1,386 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/mail"
+	"strconv"
+	"strings"
+
+	"github.com/luancpereira/APICheckout/core/currency"
+	"github.com/luancpereira/APICheckout/core/database"
+	"github.com/luancpereira/APICheckout/core/database/sqlc"
+	coreError "github.com/luancpereira/APICheckout/core/errors"
+	"github.com/luancpereira/APICheckout/core/money"
+	"github.com/shopspring/decimal"
+)
+
+// customerTotalsPageSize is the number of orders read at a time to compute the
+// totals of a customer.
+const customerTotalsPageSize = 500
+
+// CustomerUpdate holds the fields of a partial update, nil when unchanged.
+type CustomerUpdate struct {
+	Name  *string
+	Email *string
+}
+
+// CustomerTotals are the lifetime totals of the orders of a customer, deleted
+// and cancelled orders left out, each order less its refunds converted at the
+// rates of the order.
+type CustomerTotals struct {
+	Currency                                string
+	TransactionCount                        int64
+	TransactionValueUsd                     decimal.Decimal
+	TransactionValueConvertedToWishCurrency decimal.Decimal
+}
+
+/*****
+funcs for creations
+******/
+
+// CreateCustomer stores the customer with the email trimmed and lowercased,
+// which must not belong to another customer.
+func (c Checkout) CreateCustomer(name, email string) (ID int64, err error) {
+	email = normalizeEmail(email)
+
+	err = c.ValidateCustomerName(name)
+	if err != nil {
+		return
+	}
+
+	err = c.ValidateCustomerEmail(email)
+	if err != nil {
+		return
+	}
+
+	params := sqlc.InsertCustomerParams{
+		Name:  name,
+		Email: email,
+	}
+
+	ID, err = database.DB_QUERIER.InsertCustomer(context.Background(), params)
+	if err != nil {
+		err = customerDatabaseError(err, email)
+		return
+	}
+
+	return
+}
+
+/*****
+funcs for creations
+******/
+
+/*****
+funcs for updates
+******/
+
+// UpdateCustomer applies the fields set in update to the customer.
+func (c Checkout) UpdateCustomer(ID int64, update CustomerUpdate) (err error) {
+	current, err := c.GetCustomer(ID)
+	if err != nil {
+		return
+	}
+
+	params := sqlc.UpdateCustomerParams{
+		ID:    current.ID,
+		Name:  current.Name,
+		Email: current.Email,
+	}
+
+	if update.Name != nil {
+		err = c.ValidateCustomerName(*update.Name)
+		if err != nil {
+			return
+		}
+
+		params.Name = *update.Name
+	}
+
+	if update.Email != nil {
+		params.Email = normalizeEmail(*update.Email)
+
+		err = c.ValidateCustomerEmail(params.Email)
+		if err != nil {
+			return
+		}
+	}
+
+	_, err = database.DB_QUERIER.UpdateCustomer(context.Background(), params)
+	if err != nil {
+		err = customerDatabaseError(err, params.Email)
+		return
+	}
+
+	return
+}
+
+// DeleteCustomer removes the customer. Customers with orders cannot be
+// removed, not even when the orders are deleted, since those are kept for
+// audit.
+func (c Checkout) DeleteCustomer(ID int64) (err error) {
+	_, err = database.DB_QUERIER.DeleteCustomer(context.Background(), ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		err = coreError.New("error.customer.not.found", strconv.FormatInt(ID, 10))
+		return
+	}
+
+	hasTransactions := database.Utils{}.IsForeignKeyViolation(err)
+	if hasTransactions {
+		err = coreError.New("error.customer.has.transactions", strconv.FormatInt(ID, 10))
+		return
+	}
+
+	if err != nil {
+		err = database.Utils{}.CoreErrorDatabase(err)
+		return
+	}
+
+	return
+}
+
+/*****
+funcs for updates
+******/
+
+/*****
+funcs for gets
+******/
+
+func (Checkout) GetCustomer(ID int64) (customer sqlc.Customer, err error) {
+	customer, err = database.DB_QUERIER.SelectCustomerByID(context.Background(), ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		err = coreError.New("error.customer.not.found", strconv.FormatInt(ID, 10))
+		return
+	}
+
+	if err != nil {
+		err = database.Utils{}.CoreErrorDatabase(err)
+		return
+	}
+
+	return
+}
+
+func (Checkout) GetCustomers(limit, offset int64) (customers []sqlc.Customer, total int64, err error) {
+	params := sqlc.SelectCustomersParams{
+		Column1: limit,
+		Column2: offset,
+	}
+
+	customers, err = database.DB_QUERIER.SelectCustomers(context.Background(), params)
+	if err != nil {
+		err = database.Utils{}.CoreErrorDatabase(err)
+		return
+	}
+
+	total, err = database.DB_QUERIER.SelectCustomersTotal(context.Background())
+	if err != nil {
+		err = database.Utils{}.CoreErrorDatabase(err)
+		return
+	}
+
+	return
+}
+
+// GetCustomerTransactions lists the orders of the customer as GetList does,
+// together with the lifetime totals of the customer in the currency of
+// country. The totals ignore the filters and the page.
+func (c Checkout) GetCustomerTransactions(customerID int64, filters map[string]string, limit, offset int64, country string, currencies []string) (models []TransactionDetailList, total int64, totals CustomerTotals, err error) {
+	_, err = c.GetCustomer(customerID)
+	if err != nil {
+		return
+	}
+
+	customerFilters := map[string]string{}
+	for key, value := range filters {
+		customerFilters[key] = value
+	}
+	customerFilters["customer_id"] = strconv.FormatInt(customerID, 10)
+
+	models, total, err = c.GetList(customerFilters, limit, offset, country, currencies)
+	if err != nil {
+		return
+	}
+
+	totals, err = c.getCustomerTotals(customerID, country)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// getCustomerTotals converts the orders of the customer to the currency of
+// country and sums them less their refunds, converted at the rates of the
+// order, so a fully refunded order adds nothing. Cancelled orders are left out
+// and, unlike GetByID, no conversion is stored.
+func (c Checkout) getCustomerTotals(customerID int64, country string) (totals CustomerTotals, err error) {
+	targetCurrency, err := currency.Resolve(country)
+	if err != nil {
+		return
+	}
+
+	totals.Currency = targetCurrency.Code
+	target := newConversionTarget(targetCurrency, nil)
+
+	params := sqlc.SelectCustomerTransactionsParams{
+		CustomerID: customerID,
+		PageSize:   customerTotalsPageSize,
+	}
+
+	for {
+		transactions, errSelect := database.DB_QUERIER.SelectCustomerTransactions(context.Background(), params)
+		if errSelect != nil {
+			err = database.Utils{}.CoreErrorDatabase(errSelect)
+			return
+		}
+
+		orderIDs := make([]int64, len(transactions))
+		orders := make([]orderAmount, len(transactions))
+		for i, transaction := range transactions {
+			orderIDs[i] = transaction.ID
+			orders[i] = orderAmount{
+				id:                   transaction.ID,
+				transactionDate:      transaction.TransactionDate,
+				currency:             transaction.Currency,
+				value:                transaction.TransactionValue,
+				usdExchangeRate:      transaction.UsdExchangeRate,
+				usdRateEffectiveDate: transaction.UsdRateEffectiveDate,
+			}
+		}
+
+		snapshots, errSnapshots := c.getConversionSnapshots(orderIDs, []*conversionTarget{target})
+		if errSnapshots != nil {
+			err = errSnapshots
+			return
+		}
+
+		c.resolveConversionRates(orders, []*conversionTarget{target}, snapshots)
+
+		for i, transaction := range transactions {
+			converted, errConvert := c.convertNet(orders[i], transaction.Refunded, target, snapshots)
+			if errConvert != nil {
+				err = errConvert
+				return
+			}
+
+			refundedUsd := money.RoundMoney(transaction.Refunded.Div(transaction.UsdExchangeRate), money.HalfEven)
+
+			totals.TransactionCount++
+			totals.TransactionValueUsd = totals.TransactionValueUsd.Add(transaction.TransactionValueUsd.Sub(refundedUsd))
+			totals.TransactionValueConvertedToWishCurrency = totals.TransactionValueConvertedToWishCurrency.Add(converted)
+		}
+
+		if len(transactions) < customerTotalsPageSize {
+			return
+		}
+
+		params.AfterID = transactions[len(transactions)-1].ID
+	}
+}
+
+// convertNet converts the value of the order less refunded to target, both at
+// the rate the order is converted with, the stored conversion when there is
+// one. Unlike convert, it never stores the conversion.
+func (c Checkout) convertNet(order orderAmount, refunded decimal.Decimal, target *conversionTarget, snapshots map[conversionKey]sqlc.SelectConversionsRow) (net decimal.Decimal, err error) {
+	if target.err != nil {
+		err = target.err
+		return
+	}
+
+	if target.currency.Code == order.currency {
+		return order.value.Sub(refunded), nil
+	}
+
+	var targetRate, converted decimal.Decimal
+	if snapshot, found := snapshots[conversionKey{order.id, target.currency.Code}]; found && !c.Refresh {
+		targetRate, converted = snapshot.ExchangeRate, snapshot.ConvertedValue
+	} else {
+		result := c.getConversionRate(target, order.transactionDate)
+		if result.err != nil {
+			err = result.err
+			return
+		}
+
+		targetRate = result.rate
+		_, converted = result.convert(order)
+	}
+
+	refundedConverted := money.RoundMoney(refunded.Mul(targetRate).Div(order.usdExchangeRate), money.HalfEven)
+
+	return converted.Sub(refundedConverted), nil
+}
+
+/*****
+funcs for gets
+******/
+
+/*****
+funcs for validations
+******/
+
+func (Checkout) ValidateCustomerName(name string) (err error) {
+	if len(name) == 0 {
+		err = coreError.New("error.customer.name.empty")
+		return
+	}
+
+	if len(name) > 100 {
+		err = coreError.New("error.customer.name.too.long")
+		return
+	}
+
+	return
+}
+
+func (Checkout) ValidateCustomerEmail(email string) (err error) {
+	if len(email) == 0 {
+		err = coreError.New("error.customer.email.empty")
+		return
+	}
+
+	if len(email) > 255 {
+		err = coreError.New("error.customer.email.too.long")
+		return
+	}
+
+	address, errParse := mail.ParseAddress(email)
+	if errParse != nil || address.Address != email {
+		err = coreError.New("error.customer.email.invalid", email)
+		return
+	}
+
+	return
+}
+
+/*****
+funcs for validations
+******/
+
+/*****
+other funcs
+******/
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+func customerDatabaseError(err error, email string) error {
+	emailUsed := database.Utils{}.IsUniqueViolation(err)
+	if emailUsed {
+		return coreError.New("error.customer.email.already.used", email)
+	}
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return coreError.New("error.customer.not.found")
+	}
+
+	return database.Utils{}.CoreErrorDatabase(err)
+}
+
+/*****
+other funcs
+******/
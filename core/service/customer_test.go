@@ -0,0 +1,204 @@
+package service_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/luancpereira/APICheckout/core/database"
+	"github.com/luancpereira/APICheckout/core/database/sqlc"
+	coreErrors "github.com/luancpereira/APICheckout/core/errors"
+	"github.com/luancpereira/APICheckout/core/service"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateCustomer(t *testing.T) {
+	checkout := service.Checkout{}
+
+	assertKey := func(t *testing.T, err error, key string) {
+		coreErr, ok := err.(*coreErrors.CoreError)
+		assert.True(t, ok, "O erro retornado deve ser do tipo CoreError")
+		assert.Equal(t, key, coreErr.Key)
+	}
+
+	t.Run("Deve registrar o cliente com o email normalizado", func(t *testing.T) {
+		var saved sqlc.InsertCustomerParams
+		database.DB_QUERIER = MockQuerier{CustomerSaved: &saved}
+
+		ID, err := checkout.CreateCustomer("Maria Silva", " Maria@Example.com ")
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(4), ID)
+		assert.Equal(t, "maria@example.com", saved.Email)
+	})
+
+	t.Run("Deve validar o nome e o email", func(t *testing.T) {
+		var saved sqlc.InsertCustomerParams
+		database.DB_QUERIER = MockQuerier{CustomerSaved: &saved}
+
+		_, err := checkout.CreateCustomer("", "maria@example.com")
+		assertKey(t, err, "error.customer.name.empty")
+
+		_, err = checkout.CreateCustomer(strings.Repeat("a", 101), "maria@example.com")
+		assertKey(t, err, "error.customer.name.too.long")
+
+		_, err = checkout.CreateCustomer("Maria Silva", "")
+		assertKey(t, err, "error.customer.email.empty")
+
+		_, err = checkout.CreateCustomer("Maria Silva", "maria")
+		assertKey(t, err, "error.customer.email.invalid")
+
+		_, err = checkout.CreateCustomer("Maria Silva", "Maria <maria@example.com>")
+		assertKey(t, err, "error.customer.email.invalid")
+	})
+
+	t.Run("Deve retornar erro quando o email já pertencer a outro cliente", func(t *testing.T) {
+		var saved sqlc.InsertCustomerParams
+		database.DB_QUERIER = MockQuerier{CustomerSaved: &saved, InsertErr: &pq.Error{Code: "23505"}}
+
+		_, err := checkout.CreateCustomer("Maria Silva", "maria@example.com")
+		assertKey(t, err, "error.customer.email.already.used")
+	})
+}
+
+func TestUpdateAndDeleteCustomer(t *testing.T) {
+	var updated sqlc.UpdateCustomerParams
+	database.DB_QUERIER = MockQuerier{
+		Customers: map[int64]sqlc.Customer{
+			1: {ID: 1, Name: "Maria Silva", Email: "maria@example.com"},
+			2: {ID: 2, Name: "João Souza", Email: "joao@example.com"},
+		},
+		List:            []sqlc.SelectTransactionsRow{{ID: 1, CustomerID: 2}},
+		CustomerUpdated: &updated,
+	}
+	checkout := service.Checkout{}
+
+	t.Run("Deve alterar somente os campos enviados", func(t *testing.T) {
+		name := "Maria Souza"
+		err := checkout.UpdateCustomer(1, service.CustomerUpdate{Name: &name})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Maria Souza", updated.Name)
+		assert.Equal(t, "maria@example.com", updated.Email)
+	})
+
+	t.Run("Deve excluir o cliente sem pedidos", func(t *testing.T) {
+		assert.NoError(t, checkout.DeleteCustomer(1))
+	})
+
+	t.Run("Deve retornar erro ao excluir cliente com pedidos", func(t *testing.T) {
+		err := checkout.DeleteCustomer(2)
+		assert.Equal(t, "error.customer.has.transactions", err.(*coreErrors.CoreError).Key)
+	})
+
+	t.Run("Deve retornar erro de não encontrado para cliente inexistente", func(t *testing.T) {
+		assert.True(t, service.IsNotFound(checkout.UpdateCustomer(9, service.CustomerUpdate{})))
+		assert.True(t, service.IsNotFound(checkout.DeleteCustomer(9)))
+	})
+}
+
+func TestGetCustomerTransactions(t *testing.T) {
+	var params sqlc.SelectTransactionsParams
+	transactionDate := time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)
+	database.DB_QUERIER = MockQuerier{
+		Customers: map[int64]sqlc.Customer{
+			1: {ID: 1, Name: "Maria Silva", Email: "maria@example.com"},
+		},
+		List: []sqlc.SelectTransactionsRow{
+			{ID: 1, Description: "Pedido", TransactionDate: transactionDate, TransactionValue: decimal.RequireFromString("10"), Currency: "USD", UsdExchangeRate: decimal.RequireFromString("1"), TransactionValueUsd: decimal.RequireFromString("10"), CustomerID: 1},
+			{ID: 2, Description: "Pedido em reais", TransactionDate: transactionDate, TransactionValue: decimal.RequireFromString("61.92"), Currency: "BRL", UsdExchangeRate: decimal.RequireFromString("6.192"), TransactionValueUsd: decimal.RequireFromString("10"), CustomerID: 1, Status: service.StatusPaid},
+			{ID: 3, Description: "Pedido cancelado", TransactionDate: transactionDate, TransactionValue: decimal.RequireFromString("5"), Currency: "USD", UsdExchangeRate: decimal.RequireFromString("1"), TransactionValueUsd: decimal.RequireFromString("5"), CustomerID: 1, Status: service.StatusCancelled},
+		},
+		Refunds: map[int64][]sqlc.Refund{
+			2: {{ID: 1, OrderID: 2, Amount: decimal.RequireFromString("10"), RefundDate: time.Date(2025, 4, 2, 0, 0, 0, 0, time.UTC)}},
+		},
+		ListParams: &params,
+	}
+
+	checkout := service.Checkout{
+		RateProvider: service.FakeRateProvider{
+			Records: []service.Record{
+				{Country: "Brazil", CountryCurrencyDesc: "Brazil-Real", EffectiveDate: "2024-12-31", ExchangeRate: "6.192"},
+				{Country: "Brazil", CountryCurrencyDesc: "Brazil-Real", EffectiveDate: "2025-03-31", ExchangeRate: "5.8"},
+			},
+		},
+	}
+
+	t.Run("Deve listar os pedidos do cliente com os totais na moeda pedida", func(t *testing.T) {
+		models, total, totals, err := checkout.GetCustomerTransactions(1, map[string]string{}, 10, 0, "brazil", nil)
+
+		assert.NoError(t, err)
+		assert.Len(t, models, 3)
+		assert.Equal(t, int64(3), total)
+		assert.Equal(t, int64(1), params.CustomerID)
+		assert.Equal(t, "BRL", totals.Currency)
+		assert.Equal(t, int64(2), totals.TransactionCount, "o pedido cancelado fica fora dos totais")
+		assert.Equal(t, "18.39", totals.TransactionValueUsd.String(), "o reembolso de 10 reais vale 1.61 dólar na cotação do pedido")
+		assert.Equal(t, "113.84", totals.TransactionValueConvertedToWishCurrency.String())
+	})
+
+	t.Run("Deve zerar o pedido totalmente reembolsado sem guardar conversões nos totais", func(t *testing.T) {
+		var saved []sqlc.UpsertConversionParams
+		database.DB_QUERIER = MockQuerier{
+			Customers: map[int64]sqlc.Customer{2: {ID: 2, Name: "João Souza", Email: "joao@example.com"}},
+			List: []sqlc.SelectTransactionsRow{
+				{ID: 4, Description: "Pedido em euros", TransactionDate: transactionDate, TransactionValue: decimal.RequireFromString("100"), Currency: "EUR", UsdExchangeRate: decimal.RequireFromString("0.897"), TransactionValueUsd: decimal.RequireFromString("111.48"), CustomerID: 2, Status: service.StatusRefunded},
+			},
+			Refunds: map[int64][]sqlc.Refund{
+				4: {
+					{ID: 1, OrderID: 4, Amount: decimal.RequireFromString("60"), RefundDate: time.Date(2025, 2, 3, 0, 0, 0, 0, time.UTC)},
+					{ID: 2, OrderID: 4, Amount: decimal.RequireFromString("40"), RefundDate: time.Date(2025, 4, 2, 0, 0, 0, 0, time.UTC)},
+				},
+			},
+			Saved: &saved,
+		}
+
+		_, _, totals, err := checkout.GetCustomerTransactions(2, map[string]string{}, 10, 0, "brazil", nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), totals.TransactionCount)
+		assert.Equal(t, "0", totals.TransactionValueUsd.String())
+		assert.Equal(t, "0", totals.TransactionValueConvertedToWishCurrency.String())
+		assert.Len(t, saved, 1, "somente a lista guarda a conversão do pedido")
+	})
+
+	t.Run("Deve ler os pedidos do cliente em páginas", func(t *testing.T) {
+		pages := 0
+		var list []sqlc.SelectTransactionsRow
+		for ID := int64(1); ID <= 501; ID++ {
+			list = append(list, sqlc.SelectTransactionsRow{ID: ID, Description: "Pedido", TransactionDate: transactionDate, TransactionValue: decimal.NewFromInt(1), Currency: "USD", UsdExchangeRate: decimal.NewFromInt(1), TransactionValueUsd: decimal.NewFromInt(1), CustomerID: 1})
+		}
+
+		database.DB_QUERIER = MockQuerier{
+			Customers:     map[int64]sqlc.Customer{1: {ID: 1, Name: "Maria Silva", Email: "maria@example.com"}},
+			List:          list,
+			CustomerPages: &pages,
+		}
+
+		_, _, totals, err := checkout.GetCustomerTransactions(1, map[string]string{}, 10, 0, "USD", nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, pages)
+		assert.Equal(t, int64(501), totals.TransactionCount)
+		assert.Equal(t, "501", totals.TransactionValueUsd.String())
+		assert.Equal(t, "501", totals.TransactionValueConvertedToWishCurrency.String())
+	})
+
+	t.Run("Deve retornar erro de não encontrado para cliente inexistente", func(t *testing.T) {
+		_, _, _, err := checkout.GetCustomerTransactions(9, map[string]string{}, 10, 0, "brazil", nil)
+
+		assert.True(t, service.IsNotFound(err))
+	})
+
+	t.Run("Deve retornar erro de não encontrado ao criar pedido para cliente inexistente", func(t *testing.T) {
+		var inserted sqlc.InsertTransactionParams
+		database.DB_QUERIER = MockQuerier{Inserted: &inserted, InsertErr: &pq.Error{Code: "23503"}}
+
+		_, err := checkout.CreateTransactionWithItems("Pedido", transactionDate, decimal.NewFromInt(10), "USD", nil, 9)
+
+		assert.Equal(t, int64(9), inserted.CustomerID)
+		assert.True(t, service.IsNotFound(err))
+	})
+}
@@ -0,0 +1,194 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/luancpereira/APICheckout/core/config"
+	"github.com/luancpereira/APICheckout/core/currency"
+	"github.com/luancpereira/APICheckout/core/database"
+	"github.com/luancpereira/APICheckout/core/database/sqlc"
+	coreError "github.com/luancpereira/APICheckout/core/errors"
+	"github.com/shopspring/decimal"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	treasuryRatesOfExchangeURL = "https://api.fiscaldata.treasury.gov/services/api/fiscal_service/v1/accounting/od/rates_of_exchange"
+	defaultSyncInterval        = 24 * time.Hour
+	defaultSyncStartDate       = "2020-01-01"
+)
+
+type ExchangeRate struct {
+	Treasury TreasuryRateProvider
+}
+
+type SyncResult struct {
+	Inserted int64
+	Updated  int64
+	Skipped  int64
+}
+
+/*****
+funcs for syncs
+******/
+
+// StartSync keeps the rates_of_exchange table up to date with the Treasury API,
+// running a sync right away and then once every RATES_SYNC_INTERVAL. It does
+// nothing unless RATES_SYNC_ENABLED is true and the lookups are served by the
+// database provider, and fails when RATES_SYNC_ENABLED is not a boolean.
+func (e ExchangeRate) StartSync() (err error) {
+	enabled := false
+	if coreError.StringIsNotEmpty(config.RATES_SYNC_ENABLED) {
+		enabled, err = strconv.ParseBool(strings.TrimSpace(config.RATES_SYNC_ENABLED))
+		if err != nil {
+			err = coreError.New("error.config.invalid", coreError.ConcatenateStrings("RATES_SYNC_ENABLED=", config.RATES_SYNC_ENABLED))
+			return
+		}
+	}
+
+	if !enabled {
+		return
+	}
+
+	if coreError.StringIsNotEmpty(config.RATE_PROVIDER) && !strings.EqualFold(config.RATE_PROVIDER, RATE_PROVIDER_DATABASE) {
+		return
+	}
+
+	interval := defaultSyncInterval
+	if coreError.StringIsNotEmpty(config.RATES_SYNC_INTERVAL) {
+		parsed, err := time.ParseDuration(config.RATES_SYNC_INTERVAL)
+		if err != nil {
+			log.Errorf("invalid RATES_SYNC_INTERVAL %q, using %s", config.RATES_SYNC_INTERVAL, defaultSyncInterval)
+		} else {
+			interval = parsed
+		}
+	}
+
+	go func() {
+		for {
+			result, err := e.Sync()
+			if err != nil {
+				log.Errorf("rates of exchange sync failed: %s", err.Error())
+			} else {
+				log.Infof("rates of exchange synced: %d inserted, %d updated, %d skipped", result.Inserted, result.Updated, result.Skipped)
+			}
+
+			time.Sleep(interval)
+		}
+	}()
+
+	return
+}
+
+// Sync fetches from the Treasury API every record published since the last
+// record_date stored locally and upserts them into rates_of_exchange.
+func (e ExchangeRate) Sync() (result SyncResult, err error) {
+	lastRecordDate, err := database.DB_QUERIER.SelectRatesOfExchangeLastRecordDate(context.Background())
+	if err != nil {
+		err = database.Utils{}.CoreErrorDatabase(err)
+		return
+	}
+
+	if lastRecordDate == "" {
+		lastRecordDate = defaultSyncStartDate
+		if coreError.StringIsNotEmpty(config.RATES_SYNC_START_DATE) {
+			lastRecordDate = config.RATES_SYNC_START_DATE
+		}
+	}
+
+	records, err := e.Treasury.GetRecords(TreasuryQuery{Filter: "record_date:gte:" + lastRecordDate, Sort: "record_date"})
+	if err != nil {
+		return
+	}
+
+	return e.Save(records)
+}
+
+// Save upserts the records on (country, currency, effective_date), counting
+// records that were unchanged or could not be parsed as skipped.
+func (ExchangeRate) Save(records []Record) (result SyncResult, err error) {
+	for _, record := range records {
+		params, errParse := record.toUpsertParams()
+		if errParse != nil {
+			result.Skipped++
+			continue
+		}
+
+		inserted, errUpsert := database.DB_QUERIER.UpsertRateOfExchange(context.Background(), params)
+		if errors.Is(errUpsert, sql.ErrNoRows) {
+			result.Skipped++
+			continue
+		}
+
+		if errUpsert != nil {
+			err = database.Utils{}.CoreErrorDatabase(errUpsert)
+			return
+		}
+
+		if inserted {
+			result.Inserted++
+		} else {
+			result.Updated++
+		}
+	}
+
+	return
+}
+
+/*****
+funcs for syncs
+******/
+
+/*****
+other funcs
+******/
+
+func (r Record) toUpsertParams() (params sqlc.UpsertRateOfExchangeParams, err error) {
+	recordDate, err := time.Parse("2006-01-02", r.RecordDate)
+	if err != nil {
+		return
+	}
+
+	effectiveDate, err := time.Parse("2006-01-02", r.EffectiveDate)
+	if err != nil {
+		return
+	}
+
+	exchangeRate, err := decimal.NewFromString(r.ExchangeRate)
+	if err != nil {
+		return
+	}
+
+	params = sqlc.UpsertRateOfExchangeParams{
+		RecordDate:            recordDate,
+		Country:               r.Country,
+		Currency:              r.Currency,
+		CountryCurrencyDesc:   r.CountryCurrencyDesc,
+		ExchangeRate:          exchangeRate,
+		EffectiveDate:         effectiveDate,
+		SrcLineNbr:            r.SrcLineNbr,
+		RecordFiscalYear:      r.RecordFiscalYear,
+		RecordFiscalQuarter:   r.RecordFiscalQuarter,
+		RecordCalendarYear:    r.RecordCalendarYear,
+		RecordCalendarQuarter: r.RecordCalendarQuarter,
+		RecordCalendarMonth:   r.RecordCalendarMonth,
+		RecordCalendarDay:     r.RecordCalendarDay,
+	}
+
+	return
+}
+
+// toCurrency is the currency of the record for the values Resolve does not
+// find in the registry, without an ISO 4217 code.
+func (r Record) toCurrency() currency.Currency {
+	return currency.Currency{Key: r.CountryCurrencyDesc, Country: r.Country, Currency: r.Currency}
+}
+
+/*****
+other funcs
+******/
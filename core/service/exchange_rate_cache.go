@@ -0,0 +1,123 @@
+package service
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/jellydator/ttlcache/v3"
+	"github.com/luancpereira/APICheckout/core/config"
+	"github.com/luancpereira/APICheckout/core/currency"
+	coreError "github.com/luancpereira/APICheckout/core/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	defaultRateCacheTTL      = time.Hour
+	defaultRateCacheCapacity = 10000
+)
+
+// CachedRateProvider memoizes the lookups of another provider by normalized
+// currency key, target date and rate policy. Records that do not exist are
+// cached as well, so repeated lookups for a currency without rates do not
+// reach the provider.
+type CachedRateProvider struct {
+	Provider ExchangeRateProvider
+	cache    *ttlcache.Cache[string, cachedRecord]
+}
+
+type cachedRecord struct {
+	record Record
+	err    error
+}
+
+type RateCacheStats struct {
+	Hits   uint64
+	Misses uint64
+	Size   int
+}
+
+func NewCachedRateProvider(provider ExchangeRateProvider, ttl time.Duration, capacity uint64) CachedRateProvider {
+	cache := ttlcache.New[string, cachedRecord](
+		ttlcache.WithTTL[string, cachedRecord](ttl),
+		ttlcache.WithCapacity[string, cachedRecord](capacity),
+		ttlcache.WithDisableTouchOnHit[string, cachedRecord](),
+	)
+
+	return CachedRateProvider{Provider: provider, cache: cache}
+}
+
+// NewCachedRateProviderFromConfig wraps the provider with the TTL and capacity
+// set in RATE_CACHE_TTL and RATE_CACHE_CAPACITY. A TTL of 0 disables the cache.
+func NewCachedRateProviderFromConfig(provider ExchangeRateProvider) ExchangeRateProvider {
+	ttl := defaultRateCacheTTL
+	if coreError.StringIsNotEmpty(config.RATE_CACHE_TTL) {
+		parsed, err := time.ParseDuration(config.RATE_CACHE_TTL)
+		if err != nil {
+			log.Errorf("invalid RATE_CACHE_TTL %q, using %s", config.RATE_CACHE_TTL, defaultRateCacheTTL)
+		} else {
+			ttl = parsed
+		}
+	}
+
+	if ttl <= 0 {
+		return provider
+	}
+
+	var capacity uint64 = defaultRateCacheCapacity
+	if coreError.StringIsNotEmpty(config.RATE_CACHE_CAPACITY) {
+		parsed, err := strconv.ParseUint(config.RATE_CACHE_CAPACITY, 10, 64)
+		if err != nil {
+			log.Errorf("invalid RATE_CACHE_CAPACITY %q, using %d", config.RATE_CACHE_CAPACITY, defaultRateCacheCapacity)
+		} else {
+			capacity = parsed
+		}
+	}
+
+	return NewCachedRateProvider(provider, ttl, capacity)
+}
+
+func (c CachedRateProvider) FindRecord(countryCurrencyDesc string, targetDate time.Time, policy RatePolicy) (Record, error) {
+	key := rateCacheKey(countryCurrencyDesc, targetDate, policy)
+
+	if item := c.cache.Get(key); item != nil {
+		return item.Value().record, item.Value().err
+	}
+
+	record, err := c.Provider.FindRecord(countryCurrencyDesc, targetDate, policy)
+	if err == nil || isRecordNotFound(err) {
+		c.cache.Set(key, cachedRecord{record: record, err: err}, ttlcache.DefaultTTL)
+	}
+
+	return record, err
+}
+
+// FindCurrency is not cached, since Resolve only reaches it for values missing
+// from the registry.
+func (c CachedRateProvider) FindCurrency(name string) (currency.Currency, bool, error) {
+	return c.Provider.FindCurrency(name)
+}
+
+func (c CachedRateProvider) FindCurrencies(prefix string) ([]Record, error) {
+	return c.Provider.FindCurrencies(prefix)
+}
+
+func (c CachedRateProvider) FindHistory(countryCurrencyDesc, from, to string, limit, offset int64) ([]Record, int64, error) {
+	return c.Provider.FindHistory(countryCurrencyDesc, from, to, limit, offset)
+}
+
+func (c CachedRateProvider) Stats() RateCacheStats {
+	metrics := c.cache.Metrics()
+
+	return RateCacheStats{Hits: metrics.Hits, Misses: metrics.Misses, Size: c.cache.Len()}
+}
+
+func rateCacheKey(countryCurrencyDesc string, targetDate time.Time, policy RatePolicy) string {
+	return coreError.ConcatenateStrings(currency.Normalize(countryCurrencyDesc), "|", targetDate.Format("2006-01-02"), "|", policy.String())
+}
+
+func isRecordNotFound(err error) bool {
+	var coreErr *coreError.CoreError
+
+	return errors.As(err, &coreErr) && coreErr.Key == "error.not.found.value.record"
+}
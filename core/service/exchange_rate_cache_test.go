@@ -0,0 +1,84 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/luancpereira/APICheckout/core/service"
+	"github.com/stretchr/testify/assert"
+)
+
+type CountingRateProvider struct {
+	service.FakeRateProvider
+	Calls *int
+}
+
+func (c CountingRateProvider) FindRecord(countryCurrencyDesc string, targetDate time.Time, policy service.RatePolicy) (service.Record, error) {
+	*c.Calls++
+	return c.FakeRateProvider.FindRecord(countryCurrencyDesc, targetDate, policy)
+}
+
+func TestCachedRateProvider(t *testing.T) {
+	targetDate := time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)
+	records := []service.Record{{Country: "Brazil", CountryCurrencyDesc: "Brazil-Real", EffectiveDate: "2024-12-31", ExchangeRate: "6.192"}}
+
+	t.Run("Deve consultar o provedor uma única vez por moeda e data", func(t *testing.T) {
+		calls := 0
+		cached := service.NewCachedRateProvider(CountingRateProvider{service.FakeRateProvider{Records: records}, &calls}, time.Hour, 10)
+
+		first, err := cached.FindRecord("Brazil-Real", targetDate, service.DefaultRatePolicy())
+		assert.NoError(t, err)
+
+		second, err := cached.FindRecord(" brazil-real ", targetDate, service.DefaultRatePolicy())
+		assert.NoError(t, err)
+
+		assert.Equal(t, first, second)
+		assert.Equal(t, 1, calls)
+		assert.Equal(t, service.RateCacheStats{Hits: 1, Misses: 1, Size: 1}, cached.Stats())
+	})
+
+	t.Run("Deve separar as entradas por política de cotação", func(t *testing.T) {
+		calls := 0
+		cached := service.NewCachedRateProvider(CountingRateProvider{service.FakeRateProvider{Records: records}, &calls}, time.Hour, 10)
+
+		cached.FindRecord("Brazil-Real", targetDate, service.DefaultRatePolicy())
+		_, err := cached.FindRecord("Brazil-Real", targetDate, service.RatePolicy{Selection: service.RATE_SELECTION_EXACT})
+
+		assert.Error(t, err)
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("Deve guardar em cache registros não encontrados", func(t *testing.T) {
+		calls := 0
+		cached := service.NewCachedRateProvider(CountingRateProvider{service.FakeRateProvider{Records: records}, &calls}, time.Hour, 10)
+
+		_, err := cached.FindRecord("Japan-Yen", targetDate, service.DefaultRatePolicy())
+		assert.Error(t, err)
+
+		_, err = cached.FindRecord("Japan-Yen", targetDate, service.DefaultRatePolicy())
+		assert.Error(t, err)
+
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("Não deve guardar em cache outros erros", func(t *testing.T) {
+		calls := 0
+		cached := service.NewCachedRateProvider(CountingRateProvider{service.FakeRateProvider{Err: assert.AnError}, &calls}, time.Hour, 10)
+
+		cached.FindRecord("Brazil-Real", targetDate, service.DefaultRatePolicy())
+		cached.FindRecord("Brazil-Real", targetDate, service.DefaultRatePolicy())
+
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("Deve consultar o provedor novamente após o TTL", func(t *testing.T) {
+		calls := 0
+		cached := service.NewCachedRateProvider(CountingRateProvider{service.FakeRateProvider{Records: records}, &calls}, 10*time.Millisecond, 10)
+
+		cached.FindRecord("Brazil-Real", targetDate, service.DefaultRatePolicy())
+		time.Sleep(20 * time.Millisecond)
+		cached.FindRecord("Brazil-Real", targetDate, service.DefaultRatePolicy())
+
+		assert.Equal(t, 2, calls)
+	})
+}
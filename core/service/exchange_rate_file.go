@@ -0,0 +1,120 @@
+package service
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	coreError "github.com/luancpereira/APICheckout/core/errors"
+)
+
+// csvColumns maps the normalized header of a Treasury CSV export, either the
+// API field name or the label used by the fiscaldata site, to the Record field.
+var csvColumns = map[string]func(record *Record, value string){
+	"recorddate":                 func(r *Record, v string) { r.RecordDate = v },
+	"country":                    func(r *Record, v string) { r.Country = v },
+	"currency":                   func(r *Record, v string) { r.Currency = v },
+	"countrycurrencydesc":        func(r *Record, v string) { r.CountryCurrencyDesc = v },
+	"countrycurrencydescription": func(r *Record, v string) { r.CountryCurrencyDesc = v },
+	"exchangerate":               func(r *Record, v string) { r.ExchangeRate = v },
+	"effectivedate":              func(r *Record, v string) { r.EffectiveDate = v },
+	"srclinenbr":                 func(r *Record, v string) { r.SrcLineNbr = v },
+	"sourcelinenumber":           func(r *Record, v string) { r.SrcLineNbr = v },
+	"recordfiscalyear":           func(r *Record, v string) { r.RecordFiscalYear = v },
+	"fiscalyear":                 func(r *Record, v string) { r.RecordFiscalYear = v },
+	"recordfiscalquarter":        func(r *Record, v string) { r.RecordFiscalQuarter = v },
+	"fiscalquarternumber":        func(r *Record, v string) { r.RecordFiscalQuarter = v },
+	"recordcalendaryear":         func(r *Record, v string) { r.RecordCalendarYear = v },
+	"calendaryear":               func(r *Record, v string) { r.RecordCalendarYear = v },
+	"recordcalendarquarter":      func(r *Record, v string) { r.RecordCalendarQuarter = v },
+	"calendarquarternumber":      func(r *Record, v string) { r.RecordCalendarQuarter = v },
+	"recordcalendarmonth":        func(r *Record, v string) { r.RecordCalendarMonth = v },
+	"calendarmonthnumber":        func(r *Record, v string) { r.RecordCalendarMonth = v },
+	"recordcalendarday":          func(r *Record, v string) { r.RecordCalendarDay = v },
+	"calendardaynumber":          func(r *Record, v string) { r.RecordCalendarDay = v },
+}
+
+// LoadRecordsFile reads a Treasury rates_of_exchange export. JSON files may be
+// the raw API response or a plain array of records; CSV files need a header row.
+func LoadRecordsFile(path string) (records []Record, err error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		err = coreError.New("error.loading.file.error", err.Error())
+		return
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		records, err = ParseRecordsJSON(content)
+	case ".csv":
+		records, err = ParseRecordsCSV(content)
+	default:
+		err = coreError.New("error.rates.file.invalid", "unsupported extension", filepath.Ext(path))
+	}
+
+	return
+}
+
+func ParseRecordsJSON(content []byte) (records []Record, err error) {
+	content = bytes.TrimSpace(content)
+
+	if bytes.HasPrefix(content, []byte("[")) {
+		err = json.Unmarshal(content, &records)
+	} else {
+		var response Response
+		err = json.Unmarshal(content, &response)
+		records = response.Data
+	}
+
+	if err != nil {
+		err = coreError.New("error.rates.file.invalid", err.Error())
+		return
+	}
+
+	return
+}
+
+func ParseRecordsCSV(content []byte) (records []Record, err error) {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(content, []byte("\xef\xbb\xbf"))))
+
+	lines, err := reader.ReadAll()
+	if err != nil {
+		err = coreError.New("error.rates.file.invalid", err.Error())
+		return
+	}
+
+	if len(lines) == 0 {
+		return
+	}
+
+	setters := make([]func(record *Record, value string), len(lines[0]))
+	for i, column := range lines[0] {
+		setters[i] = csvColumns[normalizeColumn(column)]
+	}
+
+	for _, line := range lines[1:] {
+		var record Record
+		for i, value := range line {
+			if i < len(setters) && setters[i] != nil {
+				setters[i](&record, strings.TrimSpace(value))
+			}
+		}
+
+		records = append(records, record)
+	}
+
+	return
+}
+
+func normalizeColumn(column string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, column)
+}
@@ -0,0 +1,580 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/luancpereira/APICheckout/core/config"
+	"github.com/luancpereira/APICheckout/core/currency"
+	"github.com/luancpereira/APICheckout/core/database"
+	"github.com/luancpereira/APICheckout/core/database/sqlc"
+	coreError "github.com/luancpereira/APICheckout/core/errors"
+)
+
+const (
+	RATE_PROVIDER_DATABASE = "database"
+	RATE_PROVIDER_TREASURY = "treasury"
+	RATE_PROVIDER_FILE     = "file"
+
+	treasuryMaxPageSize  = 10000
+	treasuryFindPageSize = 100
+
+	// treasuryCurrenciesMonths is how far back the Treasury is searched for
+	// the currencies it publishes, a few quarterly publications.
+	treasuryCurrenciesMonths = 12
+)
+
+// ExchangeRateProvider resolves, under the given policy, the Treasury record
+// that applies to a transaction made in targetDate for the currency identified
+// by its Treasury country_currency_desc, the canonical key of the registry.
+// FindCurrency finds, for the currencies missing from the registry, the
+// currency whose country_currency_desc or country is name. FindCurrencies
+// returns the latest record of every currency whose country, currency or
+// country_currency_desc starts with prefix, ignoring case, sorted by
+// country_currency_desc. FindHistory returns the page at offset, of at most
+// limit records, of the records of the currency with an effective date between
+// from and to, both optional and in the 2006-01-02 layout, sorted by effective
+// date, and the number of records matching.
+type ExchangeRateProvider interface {
+	FindRecord(countryCurrencyDesc string, targetDate time.Time, policy RatePolicy) (Record, error)
+	FindCurrency(name string) (currency.Currency, bool, error)
+	FindCurrencies(prefix string) ([]Record, error)
+	FindHistory(countryCurrencyDesc, from, to string, limit, offset int64) ([]Record, int64, error)
+}
+
+// NewExchangeRateProvider builds the provider chosen by RATE_PROVIDER,
+// defaulting to the rates_of_exchange table, behind the lookup cache.
+func NewExchangeRateProvider() (provider ExchangeRateProvider, err error) {
+	switch strings.ToLower(config.RATE_PROVIDER) {
+	case "", RATE_PROVIDER_DATABASE:
+		provider = DatabaseRateProvider{}
+	case RATE_PROVIDER_TREASURY:
+		provider = TreasuryRateProvider{}
+	case RATE_PROVIDER_FILE:
+		provider, err = NewFileRateProvider(config.RATE_FILE)
+	default:
+		err = coreError.New("error.rate.provider.invalid", config.RATE_PROVIDER)
+	}
+
+	if err != nil {
+		return
+	}
+
+	provider = NewCachedRateProviderFromConfig(provider)
+
+	return
+}
+
+/*****
+database provider
+******/
+
+type DatabaseRateProvider struct{}
+
+func (DatabaseRateProvider) FindRecord(countryCurrencyDesc string, targetDate time.Time, policy RatePolicy) (record Record, err error) {
+	from, to := policy.Window(targetDate)
+
+	params := sqlc.SelectRatesOfExchangeParams{
+		CountryCurrencyDesc: countryCurrencyDesc,
+		EffectiveDateFrom:   from,
+		EffectiveDateTo:     to,
+	}
+
+	rows, err := database.DB_QUERIER.SelectRatesOfExchange(context.Background(), params)
+	if err != nil {
+		err = database.Utils{}.CoreErrorDatabase(err)
+		return
+	}
+
+	var records []Record
+	for _, row := range rows {
+		records = append(records, Record(row))
+	}
+
+	return FindRegistryWithPolicy(records, targetDate, policy)
+}
+
+// FindCurrency matches name against the stored rates, ignoring case.
+func (DatabaseRateProvider) FindCurrency(name string) (found currency.Currency, ok bool, err error) {
+	row, err := database.DB_QUERIER.SelectCurrencyByName(context.Background(), name)
+	if errors.Is(err, sql.ErrNoRows) {
+		return found, false, nil
+	}
+
+	if err != nil {
+		err = database.Utils{}.CoreErrorDatabase(err)
+		return
+	}
+
+	return Record{Country: row.Country, Currency: row.Currency, CountryCurrencyDesc: row.CountryCurrencyDesc}.toCurrency(), true, nil
+}
+
+func (DatabaseRateProvider) FindCurrencies(prefix string) (records []Record, err error) {
+	rows, err := database.DB_QUERIER.SelectCurrencies(context.Background(), database.Utils{}.EscapeLike(prefix))
+	if err != nil {
+		err = database.Utils{}.CoreErrorDatabase(err)
+		return
+	}
+
+	for _, row := range rows {
+		records = append(records, Record{
+			Country:             row.Country,
+			Currency:            row.Currency,
+			CountryCurrencyDesc: row.CountryCurrencyDesc,
+			EffectiveDate:       row.EffectiveDate,
+			ExchangeRate:        row.ExchangeRate,
+		})
+	}
+
+	return
+}
+
+func (DatabaseRateProvider) FindHistory(countryCurrencyDesc, from, to string, limit, offset int64) (records []Record, total int64, err error) {
+	params := sqlc.SelectRatesOfExchangeHistoryParams{
+		CountryCurrencyDesc: countryCurrencyDesc,
+		EffectiveDateFrom:   from,
+		EffectiveDateTo:     to,
+		PageSize:            limit,
+		PageOffset:          offset,
+	}
+
+	rows, err := database.DB_QUERIER.SelectRatesOfExchangeHistory(context.Background(), params)
+	if err != nil {
+		err = database.Utils{}.CoreErrorDatabase(err)
+		return
+	}
+
+	paramsTotal := sqlc.SelectRatesOfExchangeHistoryTotalParams{
+		CountryCurrencyDesc: countryCurrencyDesc,
+		EffectiveDateFrom:   from,
+		EffectiveDateTo:     to,
+	}
+
+	total, err = database.DB_QUERIER.SelectRatesOfExchangeHistoryTotal(context.Background(), paramsTotal)
+	if err != nil {
+		err = database.Utils{}.CoreErrorDatabase(err)
+		return
+	}
+
+	for _, row := range rows {
+		records = append(records, Record{
+			RecordDate:          row.RecordDate,
+			Country:             row.Country,
+			Currency:            row.Currency,
+			CountryCurrencyDesc: row.CountryCurrencyDesc,
+			EffectiveDate:       row.EffectiveDate,
+			ExchangeRate:        row.ExchangeRate,
+		})
+	}
+
+	return
+}
+
+/*****
+database provider
+******/
+
+/*****
+treasury provider
+******/
+
+type TreasuryRateProvider struct {
+	URL string
+}
+
+// TreasuryQuery is a query to the rates_of_exchange dataset. Filter and Sort
+// use the Treasury syntax, e.g. "country_currency_desc:eq:Brazil-Real" and
+// "-effective_date". PageSize defaults to the largest page the API serves.
+type TreasuryQuery struct {
+	Filter   string
+	Sort     string
+	PageSize int
+}
+
+// FindRecord walks the records of the policy window from the newest to the
+// oldest and stops as soon as no record further down the pages can be closer
+// to the target date than the one already selected.
+func (t TreasuryRateProvider) FindRecord(countryCurrencyDesc string, targetDate time.Time, policy RatePolicy) (record Record, err error) {
+	filter := "country_currency_desc:eq:" + countryCurrencyDesc
+
+	from, to := policy.Window(targetDate)
+	if !from.IsZero() {
+		filter = coreError.ConcatenateStrings(filter, ",effective_date:gte:", from.Format("2006-01-02"))
+	}
+
+	filter = coreError.ConcatenateStrings(filter, ",effective_date:lte:", to.Format("2006-01-02"))
+
+	query := TreasuryQuery{Filter: filter, Sort: "-effective_date", PageSize: treasuryFindPageSize}
+	targetDay := truncateToDay(targetDate)
+
+	var records []Record
+	err = t.GetPages(query, func(page []Record) (stop bool) {
+		records = append(records, page...)
+
+		closest, errFind := FindRegistryWithPolicy(records, targetDate, policy)
+		if errFind != nil || len(page) == 0 {
+			return
+		}
+
+		closestDate, _ := time.Parse("2006-01-02", closest.EffectiveDate)
+		oldestDate, errParse := time.Parse("2006-01-02", page[len(page)-1].EffectiveDate)
+		if errParse != nil {
+			return
+		}
+
+		diff := closestDate.Sub(targetDay)
+		if diff < 0 {
+			diff = -diff
+		}
+
+		return !oldestDate.After(targetDay.Add(-diff))
+	})
+	if err != nil {
+		return
+	}
+
+	return FindRegistryWithPolicy(records, targetDate, policy)
+}
+
+// FindCurrency looks for the newest record whose country_currency_desc, or
+// else whose country, is name. The Treasury filters match case.
+func (t TreasuryRateProvider) FindCurrency(name string) (found currency.Currency, ok bool, err error) {
+	for _, field := range []string{"country_currency_desc", "country"} {
+		query := TreasuryQuery{Filter: coreError.ConcatenateStrings(field, ":eq:", name), Sort: "-effective_date", PageSize: 1}
+
+		var records []Record
+		err = t.GetPages(query, func(page []Record) bool {
+			records = append(records, page...)
+			return true
+		})
+		if err != nil {
+			return
+		}
+
+		if len(records) > 0 {
+			return records[0].toCurrency(), true, nil
+		}
+	}
+
+	return
+}
+
+// FindCurrencies looks at the records published in the last
+// treasuryCurrenciesMonths, newest first, so currencies no longer published
+// are left out.
+func (t TreasuryRateProvider) FindCurrencies(prefix string) (records []Record, err error) {
+	from := time.Now().UTC().AddDate(0, -treasuryCurrenciesMonths, 0).Format("2006-01-02")
+
+	published, err := t.GetRecords(TreasuryQuery{Filter: "effective_date:gte:" + from, Sort: "-effective_date"})
+	if err != nil {
+		return
+	}
+
+	return latestRecordsByPrefix(published, prefix), nil
+}
+
+// FindHistory asks the Treasury only for the pages holding the requested
+// records, sorted by effective date.
+func (t TreasuryRateProvider) FindHistory(countryCurrencyDesc, from, to string, limit, offset int64) (records []Record, total int64, err error) {
+	filter := "country_currency_desc:eq:" + countryCurrencyDesc
+
+	if coreError.StringIsNotEmpty(from) {
+		filter = coreError.ConcatenateStrings(filter, ",effective_date:gte:", from)
+	}
+
+	if coreError.StringIsNotEmpty(to) {
+		filter = coreError.ConcatenateStrings(filter, ",effective_date:lte:", to)
+	}
+
+	query := TreasuryQuery{Filter: filter, Sort: "effective_date", PageSize: int(limit)}
+	pageSize := int64(query.pageSize())
+
+	// the Treasury pages are aligned to the page size, so an offset that is
+	// not a multiple of it spans the end of a page and the start of the next
+	pageNumber := int(offset/pageSize) + 1
+	skip := int(offset % pageSize)
+
+	records, total, err = t.GetPage(query, pageNumber)
+	if err != nil {
+		return
+	}
+
+	full := int64(len(records)) == pageSize
+	records = records[min(skip, len(records)):]
+
+	if skip > 0 && full {
+		next, _, errNext := t.GetPage(query, pageNumber+1)
+		if errNext != nil {
+			err = errNext
+			return
+		}
+
+		records = append(records, next[:min(skip, len(next))]...)
+	}
+
+	return
+}
+
+// GetRecords returns every record matching the query, following all pages.
+func (t TreasuryRateProvider) GetRecords(query TreasuryQuery) (records []Record, err error) {
+	err = t.GetPages(query, func(page []Record) bool {
+		records = append(records, page...)
+		return false
+	})
+
+	return
+}
+
+// GetPage fetches only the page pageNumber, numbered from 1, of the query and
+// returns it with meta.total-count, the number of records matching the query.
+func (t TreasuryRateProvider) GetPage(query TreasuryQuery, pageNumber int) (records []Record, total int64, err error) {
+	response, err := t.get(query, treasuryPageParams(pageNumber, query.pageSize()))
+	if err != nil {
+		return
+	}
+
+	return response.Data, int64(response.Meta.TotalCount), nil
+}
+
+// GetPages fetches the query page by page, following links.next or, when the
+// response has no links, meta.total-pages. visit is called with the records
+// of each page and ends the walk by returning true.
+func (t TreasuryRateProvider) GetPages(query TreasuryQuery, visit func(page []Record) (stop bool)) (err error) {
+	pageSize := query.pageSize()
+	next := treasuryPageParams(1, pageSize)
+
+	for pageNumber := 1; ; pageNumber++ {
+		response, errGet := t.get(query, next)
+		if errGet != nil {
+			return errGet
+		}
+
+		if visit(response.Data) || len(response.Data) == 0 {
+			return
+		}
+
+		switch {
+		case coreError.StringIsNotEmpty(response.Links.Next) && response.Links.Next != next:
+			next = response.Links.Next
+		case response.Links.Next == "" && pageNumber < response.Meta.TotalPages:
+			next = treasuryPageParams(pageNumber+1, pageSize)
+		default:
+			return
+		}
+	}
+}
+
+// get requests the query with the page parameters in page.
+func (t TreasuryRateProvider) get(query TreasuryQuery, page string) (response Response, err error) {
+	baseURL := t.URL
+	if baseURL == "" {
+		baseURL = treasuryRatesOfExchangeURL
+	}
+
+	params := url.Values{}
+	if coreError.StringIsNotEmpty(query.Filter) {
+		params.Set("filter", query.Filter)
+	}
+
+	if coreError.StringIsNotEmpty(query.Sort) {
+		params.Set("sort", query.Sort)
+	}
+
+	err = GetEntity(baseURL+"?"+params.Encode()+page, map[string]string{}, &response)
+	if err != nil {
+		if !IsUpstreamUnavailable(err) {
+			err = coreError.New("error.get.exchange.rate", err.Error())
+		}
+		return
+	}
+
+	return
+}
+
+func (q TreasuryQuery) pageSize() int {
+	if q.PageSize <= 0 || q.PageSize > treasuryMaxPageSize {
+		return treasuryMaxPageSize
+	}
+
+	return q.PageSize
+}
+
+func treasuryPageParams(pageNumber, pageSize int) string {
+	return coreError.ConcatenateStrings("&page[number]=", strconv.Itoa(pageNumber), "&page[size]=", strconv.Itoa(pageSize))
+}
+
+/*****
+treasury provider
+******/
+
+/*****
+in memory providers
+******/
+
+// FileRateProvider serves records loaded from a Treasury rates_of_exchange
+// JSON or CSV export, for environments without access to the Treasury API.
+type FileRateProvider struct {
+	Records []Record
+}
+
+func NewFileRateProvider(path string) (provider FileRateProvider, err error) {
+	records, err := LoadRecordsFile(path)
+	if err != nil {
+		return
+	}
+
+	provider = FileRateProvider{Records: records}
+
+	return
+}
+
+func (f FileRateProvider) FindRecord(countryCurrencyDesc string, targetDate time.Time, policy RatePolicy) (Record, error) {
+	return FindRegistryWithPolicy(filterRecordsByCountryCurrencyDesc(f.Records, countryCurrencyDesc), targetDate, policy)
+}
+
+func (f FileRateProvider) FindCurrency(name string) (currency.Currency, bool, error) {
+	return findCurrencyInRecords(f.Records, name)
+}
+
+func (f FileRateProvider) FindCurrencies(prefix string) ([]Record, error) {
+	return latestRecordsByPrefix(f.Records, prefix), nil
+}
+
+func (f FileRateProvider) FindHistory(countryCurrencyDesc, from, to string, limit, offset int64) ([]Record, int64, error) {
+	records, total := historyPage(filterRecordsByCountryCurrencyDesc(f.Records, countryCurrencyDesc), from, to, limit, offset)
+	return records, total, nil
+}
+
+// FakeRateProvider is an in memory provider for tests. When Err is set it is
+// returned by every lookup.
+type FakeRateProvider struct {
+	Records []Record
+	Err     error
+}
+
+func (f FakeRateProvider) FindRecord(countryCurrencyDesc string, targetDate time.Time, policy RatePolicy) (Record, error) {
+	if f.Err != nil {
+		return Record{}, f.Err
+	}
+
+	return FindRegistryWithPolicy(filterRecordsByCountryCurrencyDesc(f.Records, countryCurrencyDesc), targetDate, policy)
+}
+
+func (f FakeRateProvider) FindCurrency(name string) (currency.Currency, bool, error) {
+	if f.Err != nil {
+		return currency.Currency{}, false, f.Err
+	}
+
+	return findCurrencyInRecords(f.Records, name)
+}
+
+func (f FakeRateProvider) FindCurrencies(prefix string) ([]Record, error) {
+	if f.Err != nil {
+		return nil, f.Err
+	}
+
+	return latestRecordsByPrefix(f.Records, prefix), nil
+}
+
+func (f FakeRateProvider) FindHistory(countryCurrencyDesc, from, to string, limit, offset int64) ([]Record, int64, error) {
+	if f.Err != nil {
+		return nil, 0, f.Err
+	}
+
+	records, total := historyPage(filterRecordsByCountryCurrencyDesc(f.Records, countryCurrencyDesc), from, to, limit, offset)
+	return records, total, nil
+}
+
+func filterRecordsByCountryCurrencyDesc(records []Record, countryCurrencyDesc string) (filtered []Record) {
+	for _, record := range records {
+		if strings.EqualFold(record.CountryCurrencyDesc, strings.TrimSpace(countryCurrencyDesc)) {
+			filtered = append(filtered, record)
+		}
+	}
+
+	return
+}
+
+// findCurrencyInRecords matches name against the country_currency_desc of the
+// records and then against their country, ignoring case.
+func findCurrencyInRecords(records []Record, name string) (found currency.Currency, ok bool, err error) {
+	if filtered := filterRecordsByCountryCurrencyDesc(records, name); len(filtered) > 0 {
+		return filtered[0].toCurrency(), true, nil
+	}
+
+	for _, record := range records {
+		if strings.EqualFold(record.Country, strings.TrimSpace(name)) {
+			return record.toCurrency(), true, nil
+		}
+	}
+
+	return
+}
+
+// latestRecordsByPrefix keeps the record with the latest effective date of
+// each currency matching prefix, sorted by country_currency_desc.
+func latestRecordsByPrefix(records []Record, prefix string) (latest []Record) {
+	prefix = strings.ToLower(strings.TrimSpace(prefix))
+	positions := make(map[string]int)
+
+	for _, record := range records {
+		matches := strings.HasPrefix(strings.ToLower(record.Country), prefix) ||
+			strings.HasPrefix(strings.ToLower(record.Currency), prefix) ||
+			strings.HasPrefix(strings.ToLower(record.CountryCurrencyDesc), prefix)
+		if !matches {
+			continue
+		}
+
+		key := strings.ToLower(record.CountryCurrencyDesc)
+		position, seen := positions[key]
+		if !seen {
+			positions[key] = len(latest)
+			latest = append(latest, record)
+			continue
+		}
+
+		if record.EffectiveDate > latest[position].EffectiveDate {
+			latest[position] = record
+		}
+	}
+
+	sort.Slice(latest, func(i, j int) bool {
+		return latest[i].CountryCurrencyDesc < latest[j].CountryCurrencyDesc
+	})
+
+	return
+}
+
+// historyPage keeps the records with an effective date between from and to,
+// when given, sorted by effective date, and returns the page at offset of at
+// most limit records with the number of records kept.
+func historyPage(records []Record, from, to string, limit, offset int64) (page []Record, total int64) {
+	var history []Record
+	for _, record := range records {
+		if (from != "" && record.EffectiveDate < from) || (to != "" && record.EffectiveDate > to) {
+			continue
+		}
+
+		history = append(history, record)
+	}
+
+	sort.SliceStable(history, func(i, j int) bool {
+		return history[i].EffectiveDate < history[j].EffectiveDate
+	})
+
+	total = int64(len(history))
+	start := min(offset, total)
+	end := min(start+limit, total)
+
+	return history[start:end], total
+}
+
+/*****
+in memory providers
+******/
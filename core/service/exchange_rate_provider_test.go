@@ -0,0 +1,182 @@
+package service_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	coreErrors "github.com/luancpereira/APICheckout/core/errors"
+	"github.com/luancpereira/APICheckout/core/service"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFakeRateProvider(t *testing.T) {
+	provider := service.FakeRateProvider{
+		Records: []service.Record{
+			{Country: "Brazil", CountryCurrencyDesc: "Brazil-Real", EffectiveDate: "2024-12-31", ExchangeRate: "6.19"},
+			{Country: "Canada", CountryCurrencyDesc: "Canada-Dollar", EffectiveDate: "2024-12-31", ExchangeRate: "1.44"},
+		},
+	}
+
+	t.Run("Deve retornar o registro da moeda informada", func(t *testing.T) {
+		record, err := provider.FindRecord("brazil-real", time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC), service.DefaultRatePolicy())
+
+		assert.NoError(t, err)
+		assert.Equal(t, "6.19", record.ExchangeRate)
+	})
+
+	t.Run("Deve retornar erro para moeda sem registros", func(t *testing.T) {
+		_, err := provider.FindRecord("Japan-Yen", time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC), service.DefaultRatePolicy())
+
+		coreErr, ok := err.(*coreErrors.CoreError)
+		assert.True(t, ok, "O erro retornado deve ser do tipo CoreError")
+		assert.Equal(t, "error.not.found.value.record", coreErr.Key)
+	})
+
+	t.Run("Deve retornar o erro configurado", func(t *testing.T) {
+		_, err := service.FakeRateProvider{Err: assert.AnError}.FindRecord("Brazil-Real", time.Now(), service.DefaultRatePolicy())
+
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+
+	t.Run("Deve encontrar a moeda pela descrição ou pelo país", func(t *testing.T) {
+		found, ok, err := provider.FindCurrency("CANADA-DOLLAR")
+
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, "Canada-Dollar", found.Key)
+
+		found, ok, err = provider.FindCurrency("brazil")
+
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, "Brazil-Real", found.Key)
+
+		_, ok, err = provider.FindCurrency("Japan")
+
+		assert.NoError(t, err)
+		assert.False(t, ok)
+	})
+}
+
+func TestFileRateProvider(t *testing.T) {
+	dir := t.TempDir()
+	targetDate := time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Deve carregar um arquivo JSON no formato da API", func(t *testing.T) {
+		path := filepath.Join(dir, "rates.json")
+		content := `{"data": [{"record_date": "2024-12-31", "country": "Brazil", "currency": "Real", "country_currency_desc": "Brazil-Real", "exchange_rate": "6.192", "effective_date": "2024-12-31"}], "meta": {"count": 1}}`
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+		provider, err := service.NewFileRateProvider(path)
+		assert.NoError(t, err)
+
+		record, err := provider.FindRecord("Brazil-Real", targetDate, service.DefaultRatePolicy())
+		assert.NoError(t, err)
+		assert.Equal(t, "6.192", record.ExchangeRate)
+	})
+
+	t.Run("Deve carregar um arquivo CSV exportado do site do Tesouro", func(t *testing.T) {
+		path := filepath.Join(dir, "rates.csv")
+		content := "Record Date,Country,Currency,Country - Currency Description,Exchange Rate,Effective Date\n" +
+			"2024-12-31,Brazil,Real,Brazil-Real,6.192,2024-12-31\n" +
+			"2024-09-30,Brazil,Real,Brazil-Real,5.434,2024-09-30\n"
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+		provider, err := service.NewFileRateProvider(path)
+		assert.NoError(t, err)
+		assert.Len(t, provider.Records, 2)
+		assert.Equal(t, "Brazil-Real", provider.Records[0].CountryCurrencyDesc)
+
+		record, err := provider.FindRecord("brazil-real", targetDate, service.DefaultRatePolicy())
+		assert.NoError(t, err)
+		assert.Equal(t, "2024-12-31", record.EffectiveDate)
+	})
+
+	t.Run("Deve retornar erro para extensão não suportada", func(t *testing.T) {
+		path := filepath.Join(dir, "rates.txt")
+		assert.NoError(t, os.WriteFile(path, []byte("..."), 0o600))
+
+		_, err := service.NewFileRateProvider(path)
+
+		coreErr, ok := err.(*coreErrors.CoreError)
+		assert.True(t, ok, "O erro retornado deve ser do tipo CoreError")
+		assert.Equal(t, "error.rates.file.invalid", coreErr.Key)
+	})
+}
+
+func TestTreasuryRateProvider(t *testing.T) {
+	t.Run("Deve consultar a API com o filtro de moeda e data", func(t *testing.T) {
+		var filter string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			filter = r.URL.Query().Get("filter")
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"data": [{"country": "Brazil", "country_currency_desc": "Brazil-Real", "exchange_rate": "6.192", "effective_date": "2024-12-31"}], "meta": {"count": 1}}`))
+		}))
+		defer server.Close()
+
+		record, err := service.TreasuryRateProvider{URL: server.URL}.FindRecord("Brazil-Real", time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC), service.DefaultRatePolicy())
+
+		assert.NoError(t, err)
+		assert.Equal(t, "country_currency_desc:eq:Brazil-Real,effective_date:gte:2024-07-08,effective_date:lte:2025-01-06", filter)
+		assert.Equal(t, "6.192", record.ExchangeRate)
+	})
+
+	t.Run("Deve seguir links.next até a última página", func(t *testing.T) {
+		var pages []string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			page := r.URL.Query().Get("page[number]")
+			pages = append(pages, page)
+
+			w.WriteHeader(http.StatusOK)
+			switch page {
+			case "1":
+				w.Write([]byte(`{"data": [{"effective_date": "2024-12-31"}], "meta": {"total-pages": 2}, "links": {"next": "&page%5Bnumber%5D=2&page%5Bsize%5D=1"}}`))
+			default:
+				w.Write([]byte(`{"data": [{"effective_date": "2024-09-30"}], "meta": {"total-pages": 2}, "links": {"next": null}}`))
+			}
+		}))
+		defer server.Close()
+
+		records, err := service.TreasuryRateProvider{URL: server.URL}.GetRecords(service.TreasuryQuery{Filter: "country_currency_desc:eq:Brazil-Real", PageSize: 1})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"1", "2"}, pages)
+		assert.Len(t, records, 2)
+	})
+
+	t.Run("Deve usar meta.total-pages quando a resposta não tiver links", func(t *testing.T) {
+		requests := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"data": [{"effective_date": "2024-12-31"}], "meta": {"total-pages": 3}}`))
+		}))
+		defer server.Close()
+
+		records, err := service.TreasuryRateProvider{URL: server.URL}.GetRecords(service.TreasuryQuery{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3, requests)
+		assert.Len(t, records, 3)
+	})
+
+	t.Run("Deve ordenar no servidor e parar ao encontrar o registro", func(t *testing.T) {
+		var sorts []string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			sorts = append(sorts, r.URL.Query().Get("sort"))
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"data": [{"country_currency_desc": "Brazil-Real", "exchange_rate": "6.192", "effective_date": "2024-12-31"}], "meta": {"total-pages": 5}, "links": {"next": "&page%5Bnumber%5D=2&page%5Bsize%5D=100"}}`))
+		}))
+		defer server.Close()
+
+		record, err := service.TreasuryRateProvider{URL: server.URL}.FindRecord("Brazil-Real", time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC), service.DefaultRatePolicy())
+
+		assert.NoError(t, err)
+		assert.Equal(t, "2024-12-31", record.EffectiveDate)
+		assert.Equal(t, []string{"-effective_date"}, sorts)
+	})
+}
@@ -0,0 +1,111 @@
+package service_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/luancpereira/APICheckout/core/config"
+	"github.com/luancpereira/APICheckout/core/database"
+	"github.com/luancpereira/APICheckout/core/database/sqlc"
+	coreErrors "github.com/luancpereira/APICheckout/core/errors"
+	"github.com/luancpereira/APICheckout/core/service"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSync(t *testing.T) {
+	var filter string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		filter = r.URL.Query().Get("filter")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"data": [
+			{"record_date": "2024-12-31", "country": "Brazil", "currency": "Real", "country_currency_desc": "Brazil-Real", "effective_date": "2024-12-31", "exchange_rate": "6.192"},
+			{"record_date": "2024-12-31", "country": "Canada", "currency": "Dollar", "country_currency_desc": "Canada-Dollar", "effective_date": "2024-12-31", "exchange_rate": "1.44"},
+			{"record_date": "2024-12-31", "country": "Japan", "currency": "Yen", "country_currency_desc": "Japan-Yen", "effective_date": "2024-12-31", "exchange_rate": "157.2"}
+		], "meta": {"count": 3, "total-pages": 1}}`))
+	}))
+	defer server.Close()
+
+	exchangeRate := service.ExchangeRate{Treasury: service.TreasuryRateProvider{URL: server.URL}}
+
+	t.Run("Deve buscar a partir da última data registrada e contar inseridos, alterados e ignorados", func(t *testing.T) {
+		var upserted []sqlc.UpsertRateOfExchangeParams
+		database.DB_QUERIER = MockQuerier{
+			LastRecordDate: "2024-09-30",
+			RatesOfExchange: map[string]decimal.Decimal{
+				"Brazil-Real2024-12-31":   decimal.RequireFromString("6.192"),
+				"Canada-Dollar2024-12-31": decimal.RequireFromString("1.43"),
+			},
+			Upserted: &upserted,
+		}
+
+		result, err := exchangeRate.Sync()
+
+		assert.NoError(t, err)
+		assert.Equal(t, "record_date:gte:2024-09-30", filter)
+		assert.Equal(t, service.SyncResult{Inserted: 1, Updated: 1, Skipped: 1}, result)
+		assert.Len(t, upserted, 2)
+		assert.Equal(t, "1.44", upserted[0].ExchangeRate.String())
+	})
+
+	t.Run("Deve buscar desde a data inicial quando a tabela estiver vazia", func(t *testing.T) {
+		database.DB_QUERIER = MockQuerier{}
+
+		result, err := exchangeRate.Sync()
+
+		assert.NoError(t, err)
+		assert.Equal(t, "record_date:gte:2020-01-01", filter)
+		assert.Equal(t, int64(3), result.Inserted)
+	})
+}
+
+func TestSave(t *testing.T) {
+	t.Run("Deve ignorar registros que não podem ser lidos", func(t *testing.T) {
+		var upserted []sqlc.UpsertRateOfExchangeParams
+		database.DB_QUERIER = MockQuerier{Upserted: &upserted}
+
+		result, err := service.ExchangeRate{}.Save([]service.Record{
+			{RecordDate: "2024-12-31", CountryCurrencyDesc: "Brazil-Real", EffectiveDate: "2024-12-31", ExchangeRate: "6.192"},
+			{RecordDate: "2024-12-31", CountryCurrencyDesc: "Canada-Dollar", EffectiveDate: "31/12/2024", ExchangeRate: "1.44"},
+			{RecordDate: "2024-12-31", CountryCurrencyDesc: "Japan-Yen", EffectiveDate: "2024-12-31", ExchangeRate: "n/a"},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, service.SyncResult{Inserted: 1, Skipped: 2}, result)
+		assert.Len(t, upserted, 1)
+	})
+}
+
+func TestStartSync(t *testing.T) {
+	defer func() { config.RATES_SYNC_ENABLED = "" }()
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	exchangeRate := service.ExchangeRate{Treasury: service.TreasuryRateProvider{URL: server.URL}}
+
+	t.Run("Não deve sincronizar sem RATES_SYNC_ENABLED", func(t *testing.T) {
+		for _, value := range []string{"", "false"} {
+			config.RATES_SYNC_ENABLED = value
+
+			assert.NoError(t, exchangeRate.StartSync())
+		}
+
+		assert.Equal(t, 0, requests)
+	})
+
+	t.Run("Deve retornar erro para configuração inválida", func(t *testing.T) {
+		config.RATES_SYNC_ENABLED = "sim"
+
+		err := exchangeRate.StartSync()
+
+		coreErr, ok := err.(*coreErrors.CoreError)
+		assert.True(t, ok, "O erro retornado deve ser do tipo CoreError")
+		assert.Equal(t, "error.config.invalid", coreErr.Key)
+	})
+}
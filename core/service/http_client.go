@@ -0,0 +1,252 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/luancpereira/APICheckout/core/config"
+	coreError "github.com/luancpereira/APICheckout/core/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	defaultHTTPClientTimeout          = 10 * time.Second
+	defaultHTTPClientMaxRetries       = 3
+	defaultHTTPClientBackoff          = 200 * time.Millisecond
+	defaultHTTPClientMaxBackoff       = 5 * time.Second
+	defaultHTTPClientBreakerThreshold = 5
+	defaultHTTPClientBreakerCooldown  = 30 * time.Second
+)
+
+// DefaultHTTPClient is the client used by GetEntity for every outbound call.
+var DefaultHTTPClient = NewHTTPClientFromConfig()
+
+// HTTPClient performs outbound GETs with a request timeout, retries with
+// jittered exponential backoff on network errors, 5xx and 429 responses, and
+// a circuit breaker per host that fails fast while the upstream is unhealthy.
+type HTTPClient struct {
+	Client     *http.Client
+	MaxRetries int
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+
+	BreakerThreshold int
+	BreakerCooldown  time.Duration
+
+	breakers sync.Map
+}
+
+// CircuitBreaker opens after Threshold consecutive failed calls and rejects
+// calls during Cooldown. Once it expires a single trial call is let through:
+// a success closes the breaker and a failure opens it again.
+type CircuitBreaker struct {
+	Threshold int
+	Cooldown  time.Duration
+
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time
+	trial     bool
+}
+
+type retryableError struct {
+	err error
+}
+
+func NewHTTPClient(timeout time.Duration, maxRetries int, backoff time.Duration, breakerThreshold int, breakerCooldown time.Duration) *HTTPClient {
+	return &HTTPClient{
+		Client:           &http.Client{Timeout: timeout},
+		MaxRetries:       maxRetries,
+		Backoff:          backoff,
+		MaxBackoff:       defaultHTTPClientMaxBackoff,
+		BreakerThreshold: breakerThreshold,
+		BreakerCooldown:  breakerCooldown,
+	}
+}
+
+// NewHTTPClientFromConfig builds the client with HTTP_CLIENT_TIMEOUT,
+// HTTP_CLIENT_MAX_RETRIES, HTTP_CLIENT_BACKOFF, HTTP_CLIENT_BREAKER_THRESHOLD
+// and HTTP_CLIENT_BREAKER_COOLDOWN.
+func NewHTTPClientFromConfig() *HTTPClient {
+	return NewHTTPClient(
+		durationFromConfig("HTTP_CLIENT_TIMEOUT", config.HTTP_CLIENT_TIMEOUT, defaultHTTPClientTimeout),
+		intFromConfig("HTTP_CLIENT_MAX_RETRIES", config.HTTP_CLIENT_MAX_RETRIES, defaultHTTPClientMaxRetries),
+		durationFromConfig("HTTP_CLIENT_BACKOFF", config.HTTP_CLIENT_BACKOFF, defaultHTTPClientBackoff),
+		intFromConfig("HTTP_CLIENT_BREAKER_THRESHOLD", config.HTTP_CLIENT_BREAKER_THRESHOLD, defaultHTTPClientBreakerThreshold),
+		durationFromConfig("HTTP_CLIENT_BREAKER_COOLDOWN", config.HTTP_CLIENT_BREAKER_COOLDOWN, defaultHTTPClientBreakerCooldown),
+	)
+}
+
+func (c *HTTPClient) GetEntity(rawURL string, headers map[string]string, target interface{}) (err error) {
+	req, err := http.NewRequest("GET", rawURL, nil)
+	if err != nil {
+		return fmt.Errorf("erro ao criar a requisição: %w", err)
+	}
+
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	breaker := c.breaker(req.URL)
+	if !breaker.allow() {
+		return coreError.New("error.upstream.unavailable", req.URL.Host)
+	}
+
+	for attempt := 0; ; attempt++ {
+		err = c.do(req, target)
+
+		var retryable retryableError
+		if !errors.As(err, &retryable) {
+			breaker.record(true)
+			return
+		}
+
+		err = retryable.err
+		if attempt >= c.MaxRetries {
+			breaker.record(false)
+			return
+		}
+
+		time.Sleep(c.backoff(attempt))
+	}
+}
+
+func (c *HTTPClient) do(req *http.Request, target interface{}) error {
+	resp, err := c.Client.Do(req)
+	if err != nil {
+		return retryableError{fmt.Errorf("erro ao fazer a requisição: %w", err)}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		io.Copy(io.Discard, resp.Body)
+
+		err = fmt.Errorf("requisição falhou com status %d", resp.StatusCode)
+		if resp.StatusCode >= http.StatusInternalServerError || resp.StatusCode == http.StatusTooManyRequests {
+			return retryableError{err}
+		}
+
+		return err
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(target); err != nil {
+		return fmt.Errorf("erro ao decodificar a resposta JSON: %w", err)
+	}
+
+	return nil
+}
+
+// backoff returns a random wait between zero and Backoff * 2^attempt, capped
+// at MaxBackoff.
+func (c *HTTPClient) backoff(attempt int) time.Duration {
+	limit := c.Backoff << attempt
+	if limit <= 0 || (c.MaxBackoff > 0 && limit > c.MaxBackoff) {
+		limit = c.MaxBackoff
+	}
+
+	if limit <= 0 {
+		return 0
+	}
+
+	return rand.N(limit)
+}
+
+func (c *HTTPClient) breaker(target *url.URL) *CircuitBreaker {
+	breaker, _ := c.breakers.LoadOrStore(target.Host, &CircuitBreaker{Threshold: c.BreakerThreshold, Cooldown: c.BreakerCooldown})
+
+	return breaker.(*CircuitBreaker)
+}
+
+func (b *CircuitBreaker) allow() bool {
+	if b.Threshold <= 0 {
+		return true
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failures < b.Threshold {
+		return true
+	}
+
+	if time.Now().Before(b.openUntil) || b.trial {
+		return false
+	}
+
+	b.trial = true
+
+	return true
+}
+
+func (b *CircuitBreaker) record(success bool) {
+	if b.Threshold <= 0 {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.trial = false
+
+	if success {
+		b.failures = 0
+		return
+	}
+
+	b.failures++
+	if b.failures >= b.Threshold {
+		b.openUntil = time.Now().Add(b.Cooldown)
+	}
+}
+
+// IsUpstreamUnavailable tells whether err was returned by an open circuit
+// breaker.
+func IsUpstreamUnavailable(err error) bool {
+	var coreErr *coreError.CoreError
+
+	return errors.As(err, &coreErr) && coreErr.Key == "error.upstream.unavailable"
+}
+
+func (e retryableError) Error() string {
+	return e.err.Error()
+}
+
+func (e retryableError) Unwrap() error {
+	return e.err
+}
+
+func durationFromConfig(name, value string, fallback time.Duration) time.Duration {
+	if !coreError.StringIsNotEmpty(value) {
+		return fallback
+	}
+
+	parsed, err := time.ParseDuration(value)
+	if err != nil || parsed < 0 {
+		log.Errorf("invalid %s %q, using %s", name, value, fallback)
+		return fallback
+	}
+
+	return parsed
+}
+
+func intFromConfig(name, value string, fallback int) int {
+	if !coreError.StringIsNotEmpty(value) {
+		return fallback
+	}
+
+	parsed, err := strconv.Atoi(value)
+	if err != nil || parsed < 0 {
+		log.Errorf("invalid %s %q, using %d", name, value, fallback)
+		return fallback
+	}
+
+	return parsed
+}
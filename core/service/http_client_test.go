@@ -0,0 +1,97 @@
+package service_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	coreErrors "github.com/luancpereira/APICheckout/core/errors"
+	"github.com/luancpereira/APICheckout/core/service"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPClient(t *testing.T) {
+	t.Run("Deve repetir a requisição em erros 5xx e 429", func(t *testing.T) {
+		statuses := []int{http.StatusBadGateway, http.StatusTooManyRequests, http.StatusOK}
+		requests := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(statuses[requests])
+			requests++
+			w.Write([]byte(`{"name": "Test", "value": "12345"}`))
+		}))
+		defer server.Close()
+
+		var result MockResponse
+		err := service.NewHTTPClient(time.Second, 3, time.Millisecond, 5, time.Minute).GetEntity(server.URL, nil, &result)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3, requests)
+		assert.Equal(t, "Test", result.Name)
+	})
+
+	t.Run("Não deve repetir a requisição em erros 4xx", func(t *testing.T) {
+		requests := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			w.WriteHeader(http.StatusBadRequest)
+		}))
+		defer server.Close()
+
+		var result MockResponse
+		err := service.NewHTTPClient(time.Second, 3, time.Millisecond, 5, time.Minute).GetEntity(server.URL, nil, &result)
+
+		assert.Error(t, err)
+		assert.Equal(t, 1, requests)
+	})
+
+	t.Run("Deve retornar erro quando o servidor exceder o timeout", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			time.Sleep(100 * time.Millisecond)
+		}))
+		defer server.Close()
+
+		var result MockResponse
+		err := service.NewHTTPClient(10*time.Millisecond, 1, time.Millisecond, 5, time.Minute).GetEntity(server.URL, nil, &result)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "erro ao fazer a requisição")
+	})
+
+	t.Run("Deve abrir o circuito após falhas consecutivas e fechar após o intervalo", func(t *testing.T) {
+		healthy := false
+		requests := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			if !healthy {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"name": "Test"}`))
+		}))
+		defer server.Close()
+
+		client := service.NewHTTPClient(time.Second, 0, time.Millisecond, 2, 50*time.Millisecond)
+
+		var result MockResponse
+		assert.Error(t, client.GetEntity(server.URL, nil, &result))
+		assert.Error(t, client.GetEntity(server.URL, nil, &result))
+
+		err := client.GetEntity(server.URL, nil, &result)
+
+		coreErr, ok := err.(*coreErrors.CoreError)
+		assert.True(t, ok, "O erro retornado deve ser do tipo CoreError")
+		assert.Equal(t, "error.upstream.unavailable", coreErr.Key)
+		assert.True(t, service.IsUpstreamUnavailable(err))
+		assert.Equal(t, 2, requests)
+
+		healthy = true
+		time.Sleep(60 * time.Millisecond)
+
+		assert.NoError(t, client.GetEntity(server.URL, nil, &result))
+		assert.NoError(t, client.GetEntity(server.URL, nil, &result))
+		assert.Equal(t, 4, requests)
+	})
+}
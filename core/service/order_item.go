@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+
+	"github.com/luancpereira/APICheckout/core/database"
+	"github.com/luancpereira/APICheckout/core/database/sqlc"
+	coreError "github.com/luancpereira/APICheckout/core/errors"
+	"github.com/luancpereira/APICheckout/core/money"
+	"github.com/shopspring/decimal"
+)
+
+// TransactionItem is a line of an order, priced in the order currency.
+type TransactionItem struct {
+	Sku       string
+	Name      string
+	Quantity  int32
+	UnitPrice decimal.Decimal
+}
+
+// TransactionItemDetail is a stored line of an order with its total, both
+// converted with the exchange rate of the order.
+type TransactionItemDetail struct {
+	sqlc.OrderItem
+	Total                            decimal.Decimal
+	UnitPriceConvertedToWishCurrency decimal.Decimal
+	TotalConvertedToWishCurrency     decimal.Decimal
+}
+
+/*****
+funcs for creations
+******/
+
+// prepareItems validates the items, rounds their unit prices half-up to cents
+// and returns their total as the value of the order. Without items the value
+// is returned as given; with items a value that is set must match the total.
+func (c Checkout) prepareItems(transaction_value decimal.Decimal, items []TransactionItem) (value decimal.Decimal, prepared []TransactionItem, err error) {
+	if len(items) == 0 {
+		return transaction_value, nil, nil
+	}
+
+	value = decimal.Zero
+
+	for _, item := range items {
+		err = c.ValidateItem(item)
+		if err != nil {
+			return
+		}
+
+		item.UnitPrice = money.RoundMoney(item.UnitPrice, money.HalfUp)
+		value = value.Add(item.UnitPrice.Mul(decimal.NewFromInt32(item.Quantity)))
+		prepared = append(prepared, item)
+	}
+
+	if !transaction_value.IsZero() && !money.RoundMoney(transaction_value, money.HalfUp).Equal(value) {
+		err = coreError.New("error.items.total.mismatch", value.String())
+		return
+	}
+
+	return
+}
+
+// setItems adds the items to the insert of the order, so the order and its
+// items are stored by the same statement.
+func setItems(params *sqlc.InsertTransactionParams, items []TransactionItem) {
+	for _, item := range items {
+		params.ItemSkus = append(params.ItemSkus, item.Sku)
+		params.ItemNames = append(params.ItemNames, item.Name)
+		params.ItemQuantities = append(params.ItemQuantities, item.Quantity)
+		params.ItemUnitPrices = append(params.ItemUnitPrices, item.UnitPrice)
+	}
+}
+
+/*****
+funcs for creations
+******/
+
+/*****
+funcs for gets
+******/
+
+// getItems returns the items of the order converted with the rates of the
+// conversion of the order, legs.
+func (Checkout) getItems(orderID int64, legs RateLegs) (items []TransactionItemDetail, err error) {
+	rows, err := database.DB_QUERIER.SelectTransactionItems(context.Background(), orderID)
+	if err != nil {
+		err = database.Utils{}.CoreErrorDatabase(err)
+		return
+	}
+
+	for _, row := range rows {
+		total := row.UnitPrice.Mul(decimal.NewFromInt32(row.Quantity))
+
+		items = append(items, TransactionItemDetail{
+			OrderItem:                        row,
+			Total:                            total,
+			UnitPriceConvertedToWishCurrency: legs.convert(row.UnitPrice),
+			TotalConvertedToWishCurrency:     legs.convert(total),
+		})
+	}
+
+	return
+}
+
+/*****
+funcs for gets
+******/
+
+/*****
+funcs for validations
+******/
+
+func (c Checkout) ValidateItem(item TransactionItem) (err error) {
+	if len(item.Sku) == 0 {
+		err = coreError.New("error.item.sku.empty")
+		return
+	}
+
+	if len(item.Sku) > 50 {
+		err = coreError.New("error.item.sku.too.long")
+		return
+	}
+
+	if len(item.Name) == 0 {
+		err = coreError.New("error.item.name.empty")
+		return
+	}
+
+	if len(item.Name) > 100 {
+		err = coreError.New("error.item.name.too.long")
+		return
+	}
+
+	if item.Quantity <= 0 {
+		err = coreError.New("error.item.quantity.not.positive")
+		return
+	}
+
+	return c.ValidateTrasactionValue(item.UnitPrice)
+}
+
+/*****
+funcs for validations
+******/
@@ -0,0 +1,123 @@
+package service_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/luancpereira/APICheckout/core/database"
+	"github.com/luancpereira/APICheckout/core/database/sqlc"
+	coreErrors "github.com/luancpereira/APICheckout/core/errors"
+	"github.com/luancpereira/APICheckout/core/service"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateTransactionWithItems(t *testing.T) {
+	var inserted sqlc.InsertTransactionParams
+	database.DB_QUERIER = MockQuerier{Inserted: &inserted}
+
+	checkout := service.Checkout{
+		RateProvider: service.FakeRateProvider{
+			Records: []service.Record{
+				{Country: "Brazil", CountryCurrencyDesc: "Brazil-Real", EffectiveDate: "2024-12-31", ExchangeRate: "6.192"},
+			},
+		},
+	}
+	transactionDate := time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)
+	items := []service.TransactionItem{
+		{Sku: "CAM-01", Name: "Camiseta", Quantity: 2, UnitPrice: decimal.RequireFromString("19.995")},
+		{Sku: "BON-01", Name: "Boné", Quantity: 1, UnitPrice: decimal.RequireFromString("21.92")},
+	}
+
+	assertKey := func(t *testing.T, err error, key string) {
+		coreErr, ok := err.(*coreErrors.CoreError)
+		assert.True(t, ok, "O erro retornado deve ser do tipo CoreError")
+		assert.Equal(t, key, coreErr.Key)
+	}
+
+	t.Run("Deve calcular o valor do pedido pelos itens", func(t *testing.T) {
+		_, err := checkout.CreateTransactionWithItems("Pedido", transactionDate, decimal.Zero, "BRL", items, 0)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "61.92", inserted.TransactionValue.String())
+		assert.Equal(t, "10", inserted.TransactionValueUsd.String())
+		assert.Equal(t, []string{"CAM-01", "BON-01"}, inserted.ItemSkus)
+		assert.Equal(t, []int32{2, 1}, inserted.ItemQuantities)
+		assert.Equal(t, "20", inserted.ItemUnitPrices[0].String())
+	})
+
+	t.Run("Deve aceitar o valor informado igual ao total dos itens", func(t *testing.T) {
+		_, err := checkout.CreateTransactionWithItems("Pedido", transactionDate, decimal.RequireFromString("61.92"), "BRL", items, 0)
+		assert.NoError(t, err)
+
+		_, err = checkout.CreateTransactionWithItems("Pedido", transactionDate, decimal.RequireFromString("61.91"), "BRL", items, 0)
+		assertKey(t, err, "error.items.total.mismatch")
+	})
+
+	t.Run("Deve validar os itens", func(t *testing.T) {
+		invalid := []struct {
+			item service.TransactionItem
+			key  string
+		}{
+			{service.TransactionItem{Name: "Camiseta", Quantity: 1, UnitPrice: decimal.NewFromInt(1)}, "error.item.sku.empty"},
+			{service.TransactionItem{Sku: strings.Repeat("A", 51), Name: "Camiseta", Quantity: 1, UnitPrice: decimal.NewFromInt(1)}, "error.item.sku.too.long"},
+			{service.TransactionItem{Sku: "CAM-01", Quantity: 1, UnitPrice: decimal.NewFromInt(1)}, "error.item.name.empty"},
+			{service.TransactionItem{Sku: "CAM-01", Name: strings.Repeat("a", 101), Quantity: 1, UnitPrice: decimal.NewFromInt(1)}, "error.item.name.too.long"},
+			{service.TransactionItem{Sku: "CAM-01", Name: "Camiseta", UnitPrice: decimal.NewFromInt(1)}, "error.item.quantity.not.positive"},
+			{service.TransactionItem{Sku: "CAM-01", Name: "Camiseta", Quantity: 1}, "error.value.not.positive"},
+		}
+
+		for _, tc := range invalid {
+			_, err := checkout.CreateTransactionWithItems("Pedido", transactionDate, decimal.Zero, "BRL", []service.TransactionItem{tc.item}, 0)
+			assertKey(t, err, tc.key)
+		}
+	})
+
+	t.Run("Deve exigir o valor quando não houver itens", func(t *testing.T) {
+		_, err := checkout.CreateTransactionWithItems("Pedido", transactionDate, decimal.Zero, "BRL", nil, 0)
+
+		assertKey(t, err, "error.value.not.positive")
+	})
+}
+
+func TestGetByIDItems(t *testing.T) {
+	database.DB_QUERIER = MockQuerier{
+		Transactions: map[int64]sqlc.SelectTransactionByIDRow{
+			1: {ID: 1, Description: "Pedido em reais", TransactionDate: time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC), TransactionValue: decimal.RequireFromString("61.92"), Currency: "BRL", UsdExchangeRate: decimal.RequireFromString("6.192"), TransactionValueUsd: decimal.RequireFromString("10")},
+		},
+		Items: map[int64][]sqlc.OrderItem{
+			1: {
+				{ID: 1, OrderID: 1, Sku: "CAM-01", Name: "Camiseta", Quantity: 2, UnitPrice: decimal.RequireFromString("20")},
+				{ID: 2, OrderID: 1, Sku: "BON-01", Name: "Boné", Quantity: 1, UnitPrice: decimal.RequireFromString("21.92")},
+			},
+		},
+	}
+
+	checkout := service.Checkout{
+		RateProvider: service.FakeRateProvider{
+			Records: []service.Record{
+				{Country: "Canada", CountryCurrencyDesc: "Canada-Dollar", EffectiveDate: "2024-12-31", ExchangeRate: "1.44"},
+			},
+		},
+	}
+
+	t.Run("Deve converter os itens com a cotação do pedido", func(t *testing.T) {
+		transaction, err := checkout.GetByID(1, "canada", nil)
+
+		assert.NoError(t, err)
+		assert.Len(t, transaction.Items, 2)
+		assert.Equal(t, "40", transaction.Items[0].Total.String())
+		assert.Equal(t, "4.65", transaction.Items[0].UnitPriceConvertedToWishCurrency.String())
+		assert.Equal(t, "9.3", transaction.Items[0].TotalConvertedToWishCurrency.String())
+		assert.Equal(t, "5.1", transaction.Items[1].TotalConvertedToWishCurrency.String())
+	})
+
+	t.Run("Deve manter o preço dos itens na moeda do pedido", func(t *testing.T) {
+		transaction, err := checkout.GetByID(1, "BRL", nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "20", transaction.Items[0].UnitPriceConvertedToWishCurrency.String())
+		assert.Equal(t, "21.92", transaction.Items[1].TotalConvertedToWishCurrency.String())
+	})
+}
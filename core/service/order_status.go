@@ -0,0 +1,216 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/luancpereira/APICheckout/core/database"
+	"github.com/luancpereira/APICheckout/core/database/sqlc"
+	coreError "github.com/luancpereira/APICheckout/core/errors"
+)
+
+const (
+	StatusPending   = "pending"
+	StatusPaid      = "paid"
+	StatusCancelled = "cancelled"
+	StatusRefunded  = "refunded"
+)
+
+// statusTransitions lists, for each status, the statuses an order can move
+// to. Cancelled and refunded orders are final.
+var statusTransitions = map[string][]string{
+	StatusPending:   {StatusPaid, StatusCancelled},
+	StatusPaid:      {StatusRefunded},
+	StatusCancelled: nil,
+	StatusRefunded:  nil,
+}
+
+// StatusTransition is a change of status of an order and the reason given
+// for it.
+type StatusTransition struct {
+	ID         int64
+	FromStatus string
+	ToStatus   string
+	Reason     string
+	CreatedAt  time.Time
+}
+
+/*****
+funcs for updates
+******/
+
+// TransitionTransaction moves the order to status when the state machine
+// allows it from the current status, and records the transition with its
+// reason. Deleted orders cannot change status, and paid orders move to
+// refunded only once their refunds cover the whole value.
+func (c Checkout) TransitionTransaction(ID int64, status, reason string) (transition StatusTransition, err error) {
+	status = strings.ToLower(strings.TrimSpace(status))
+
+	err = c.ValidateStatus(status)
+	if err != nil {
+		return
+	}
+
+	err = c.ValidateStatusReason(reason)
+	if err != nil {
+		return
+	}
+
+	order, err := c.getTransaction(ID, false)
+	if err != nil {
+		return
+	}
+
+	err = c.ValidateStatusTransition(order.Status, status)
+	if err != nil {
+		return
+	}
+
+	params := sqlc.TransitionTransactionStatusParams{
+		OrderID:    order.ID,
+		FromStatus: order.Status,
+		ToStatus:   status,
+		Reason:     reason,
+	}
+
+	var row sqlc.TransitionTransactionStatusRow
+
+	// the update only happens while the order is still in the status read
+	// above, so a concurrent transition is reported against the new status
+	err = database.DB_TRANSACTION(func(querier sqlc.Querier) (err error) {
+		if status == StatusRefunded {
+			err = c.validateFullyRefunded(querier, order.ID)
+			if err != nil {
+				return
+			}
+		}
+
+		row, err = querier.TransitionTransactionStatus(context.Background(), params)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			err = database.Utils{}.CoreErrorDatabase(err)
+			return
+		}
+
+		return
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		order, err = c.getTransaction(ID, false)
+		if err != nil {
+			return
+		}
+
+		err = c.ValidateStatusTransition(order.Status, status)
+		if err == nil {
+			err = coreError.New("error.status.transition.invalid", coreError.ConcatenateStrings(params.FromStatus, " -> ", status))
+		}
+
+		return
+	}
+
+	if err != nil {
+		return
+	}
+
+	transition = StatusTransition{
+		ID:         row.ID,
+		FromStatus: params.FromStatus,
+		ToStatus:   params.ToStatus,
+		Reason:     params.Reason,
+		CreatedAt:  row.CreatedAt,
+	}
+
+	return
+}
+
+/*****
+funcs for updates
+******/
+
+/*****
+funcs for gets
+******/
+
+// GetStatusHistory returns the transitions of the order, oldest first.
+func (c Checkout) GetStatusHistory(ID int64) (transitions []StatusTransition, err error) {
+	_, err = c.getTransaction(ID, c.IncludeDeleted)
+	if err != nil {
+		return
+	}
+
+	rows, err := database.DB_QUERIER.SelectTransactionStatusHistory(context.Background(), ID)
+	if err != nil {
+		err = database.Utils{}.CoreErrorDatabase(err)
+		return
+	}
+
+	for _, row := range rows {
+		transitions = append(transitions, StatusTransition{
+			ID:         row.ID,
+			FromStatus: row.FromStatus,
+			ToStatus:   row.ToStatus,
+			Reason:     row.Reason,
+			CreatedAt:  row.CreatedAt,
+		})
+	}
+
+	return
+}
+
+/*****
+funcs for gets
+******/
+
+/*****
+funcs for validations
+******/
+
+func (Checkout) ValidateStatus(status string) (err error) {
+	if _, found := statusTransitions[status]; !found {
+		err = coreError.New("error.status.invalid", status)
+		return
+	}
+
+	return
+}
+
+func (Checkout) ValidateStatusReason(reason string) (err error) {
+	if len(reason) > 255 {
+		err = coreError.New("error.status.reason.too.long")
+		return
+	}
+
+	return
+}
+
+// ValidateStatusTransition reports error.status.unchanged when the order is
+// already in status to, error.status.final when from allows no transition and
+// error.status.transition.invalid when it allows others.
+func (Checkout) ValidateStatusTransition(from, to string) (err error) {
+	if from == to {
+		err = coreError.New("error.status.unchanged", to)
+		return
+	}
+
+	allowed := statusTransitions[from]
+	if len(allowed) == 0 {
+		err = coreError.New("error.status.final", from)
+		return
+	}
+
+	for _, status := range allowed {
+		if status == to {
+			return
+		}
+	}
+
+	err = coreError.New("error.status.transition.invalid", coreError.ConcatenateStrings(from, " -> ", to))
+
+	return
+}
+
+/*****
+funcs for validations
+******/
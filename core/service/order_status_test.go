@@ -0,0 +1,129 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/luancpereira/APICheckout/core/database"
+	"github.com/luancpereira/APICheckout/core/database/sqlc"
+	coreErrors "github.com/luancpereira/APICheckout/core/errors"
+	"github.com/luancpereira/APICheckout/core/service"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateStatusTransition(t *testing.T) {
+	checkout := service.Checkout{}
+
+	t.Run("Deve permitir as transições da máquina de estados", func(t *testing.T) {
+		assert.NoError(t, checkout.ValidateStatusTransition(service.StatusPending, service.StatusPaid))
+		assert.NoError(t, checkout.ValidateStatusTransition(service.StatusPending, service.StatusCancelled))
+		assert.NoError(t, checkout.ValidateStatusTransition(service.StatusPaid, service.StatusRefunded))
+	})
+
+	t.Run("Deve retornar uma chave de erro para cada transição inválida", func(t *testing.T) {
+		err := checkout.ValidateStatusTransition(service.StatusPaid, service.StatusPaid)
+		assert.Equal(t, "error.status.unchanged", err.(*coreErrors.CoreError).Key)
+
+		err = checkout.ValidateStatusTransition(service.StatusPending, service.StatusRefunded)
+		assert.Equal(t, "error.status.transition.invalid", err.(*coreErrors.CoreError).Key)
+
+		err = checkout.ValidateStatusTransition(service.StatusPaid, service.StatusPending)
+		assert.Equal(t, "error.status.transition.invalid", err.(*coreErrors.CoreError).Key)
+
+		err = checkout.ValidateStatusTransition(service.StatusCancelled, service.StatusPaid)
+		assert.Equal(t, "error.status.final", err.(*coreErrors.CoreError).Key)
+
+		err = checkout.ValidateStatusTransition(service.StatusRefunded, service.StatusPaid)
+		assert.Equal(t, "error.status.final", err.(*coreErrors.CoreError).Key)
+	})
+}
+
+func TestTransitionTransaction(t *testing.T) {
+	var transitioned sqlc.TransitionTransactionStatusParams
+	createdAt := time.Date(2025, 1, 6, 12, 0, 0, 0, time.UTC)
+	database.DB_QUERIER = MockQuerier{
+		Transactions: map[int64]sqlc.SelectTransactionByIDRow{
+			1: {ID: 1, Description: "Pedido", TransactionValue: decimal.RequireFromString("10"), Currency: "USD", UsdExchangeRate: decimal.RequireFromString("1"), Status: service.StatusPending},
+			2: {ID: 2, Description: "Pedido anulado", TransactionValue: decimal.RequireFromString("10"), Currency: "USD", UsdExchangeRate: decimal.RequireFromString("1"), Status: service.StatusPending, Deleted: true},
+			3: {ID: 3, Description: "Pedido pago", TransactionValue: decimal.RequireFromString("10"), Currency: "USD", UsdExchangeRate: decimal.RequireFromString("1"), Status: service.StatusPaid},
+			4: {ID: 4, Description: "Pedido pago e reembolsado", TransactionValue: decimal.RequireFromString("10"), Currency: "USD", UsdExchangeRate: decimal.RequireFromString("1"), Status: service.StatusPaid},
+		},
+		Refunds: map[int64][]sqlc.Refund{
+			3: {{ID: 1, OrderID: 3, Amount: decimal.RequireFromString("4")}},
+			4: {{ID: 2, OrderID: 4, Amount: decimal.RequireFromString("4")}, {ID: 3, OrderID: 4, Amount: decimal.RequireFromString("6")}},
+		},
+		History: map[int64][]sqlc.OrderStatusHistory{
+			1: {{ID: 4, OrderID: 1, FromStatus: service.StatusPending, ToStatus: service.StatusPaid, Reason: "Pagamento aprovado", CreatedAt: createdAt}},
+		},
+		Transitioned: &transitioned,
+	}
+	checkout := service.Checkout{}
+
+	t.Run("Deve registrar a transição com o motivo", func(t *testing.T) {
+		transition, err := checkout.TransitionTransaction(1, " Paid ", "Pagamento aprovado")
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(5), transition.ID)
+		assert.Equal(t, service.StatusPending, transition.FromStatus)
+		assert.Equal(t, service.StatusPaid, transition.ToStatus)
+		assert.Equal(t, service.StatusPending, transitioned.FromStatus)
+		assert.Equal(t, service.StatusPaid, transitioned.ToStatus)
+		assert.Equal(t, "Pagamento aprovado", transitioned.Reason)
+	})
+
+	t.Run("Deve retornar erro para status desconhecido ou transição inválida", func(t *testing.T) {
+		_, err := checkout.TransitionTransaction(1, "shipped", "")
+		assert.Equal(t, "error.status.invalid", err.(*coreErrors.CoreError).Key)
+
+		_, err = checkout.TransitionTransaction(1, service.StatusRefunded, "")
+		assert.Equal(t, "error.status.transition.invalid", err.(*coreErrors.CoreError).Key)
+	})
+
+	t.Run("Deve reembolsar o pedido somente quando os reembolsos cobrem o valor", func(t *testing.T) {
+		_, err := checkout.TransitionTransaction(3, service.StatusRefunded, "Devolvido")
+		assert.Equal(t, "error.status.refunds.incomplete", err.(*coreErrors.CoreError).Key)
+		assert.Equal(t, "Transaction can only be refunded once the refunds cover its value, amount still refundable: 6", err.(*coreErrors.CoreError).Message)
+
+		transition, err := checkout.TransitionTransaction(4, service.StatusRefunded, "Devolvido")
+		assert.NoError(t, err)
+		assert.Equal(t, service.StatusPaid, transition.FromStatus)
+		assert.Equal(t, service.StatusRefunded, transitioned.ToStatus)
+	})
+
+	t.Run("Deve retornar erro de não encontrado para pedido inexistente ou excluído", func(t *testing.T) {
+		_, err := checkout.TransitionTransaction(9, service.StatusPaid, "")
+		assert.True(t, service.IsNotFound(err))
+
+		_, err = checkout.TransitionTransaction(2, service.StatusPaid, "")
+		assert.True(t, service.IsNotFound(err))
+	})
+
+	t.Run("Deve retornar o histórico de transições do pedido", func(t *testing.T) {
+		transitions, err := checkout.GetStatusHistory(1)
+
+		assert.NoError(t, err)
+		assert.Len(t, transitions, 1)
+		assert.Equal(t, service.StatusPaid, transitions[0].ToStatus)
+		assert.Equal(t, createdAt, transitions[0].CreatedAt)
+	})
+}
+
+func TestGetListStatusFilter(t *testing.T) {
+	var params sqlc.SelectTransactionsParams
+	database.DB_QUERIER = MockQuerier{ListParams: &params}
+	checkout := service.Checkout{}
+
+	t.Run("Deve filtrar a lista pelo status", func(t *testing.T) {
+		_, _, err := checkout.GetList(map[string]string{"status": "PAID"}, 10, 0, "USD", nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, service.StatusPaid, params.Status)
+	})
+
+	t.Run("Deve retornar erro para status desconhecido", func(t *testing.T) {
+		_, _, err := checkout.GetList(map[string]string{"status": "shipped"}, 10, 0, "USD", nil)
+
+		assert.Equal(t, "error.status.invalid", err.(*coreErrors.CoreError).Key)
+	})
+}
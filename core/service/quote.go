@@ -0,0 +1,203 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/luancpereira/APICheckout/core/config"
+	"github.com/luancpereira/APICheckout/core/currency"
+	"github.com/luancpereira/APICheckout/core/database"
+	"github.com/luancpereira/APICheckout/core/database/sqlc"
+	coreError "github.com/luancpereira/APICheckout/core/errors"
+	"github.com/luancpereira/APICheckout/core/money"
+	"github.com/shopspring/decimal"
+)
+
+const defaultQuoteTTL = 10 * time.Minute
+
+// Quote is a USD amount converted to a currency at the rate in force when it
+// was created. Until ExpiresAt a single order can be locked to it.
+type Quote struct {
+	ID              int64
+	Currency        string
+	Amount          decimal.Decimal
+	ExchangeRate    decimal.Decimal
+	ConvertedAmount decimal.Decimal
+	EffectiveDate   string
+	ExpiresAt       time.Time
+}
+
+/*****
+funcs for creations
+******/
+
+// CreateQuote converts the USD amount to the currency at today's rate and
+// stores the quote for QUOTE_TTL (default 10m). The amount is rounded half-up
+// to cents and the converted amount half-even.
+func (c Checkout) CreateQuote(amount decimal.Decimal, quote_currency string) (quote Quote, err error) {
+	err = c.ValidateTrasactionValue(amount)
+	if err != nil {
+		return
+	}
+
+	amount = money.RoundMoney(amount, money.HalfUp)
+
+	quoteCurrency, err := currency.ResolveCode(quote_currency)
+	if err != nil {
+		return
+	}
+
+	exchangeRate, record, err := c.getExchangeRate(time.Now(), quoteCurrency)
+	if err != nil {
+		return
+	}
+
+	quote = Quote{
+		Currency:        quoteCurrency.Code,
+		Amount:          amount,
+		ExchangeRate:    exchangeRate,
+		ConvertedAmount: money.RoundMoney(amount.Mul(exchangeRate), money.HalfEven),
+		EffectiveDate:   record.EffectiveDate,
+	}
+
+	params := sqlc.InsertQuoteParams{
+		Currency:        quote.Currency,
+		Amount:          quote.Amount,
+		ExchangeRate:    quote.ExchangeRate,
+		ConvertedAmount: quote.ConvertedAmount,
+		EffectiveDate:   quote.EffectiveDate,
+		TtlSeconds:      int64(quoteTTL().Seconds()),
+	}
+
+	inserted, err := database.DB_QUERIER.InsertQuote(context.Background(), params)
+	if err != nil {
+		err = database.Utils{}.CoreErrorDatabase(err)
+		return
+	}
+
+	quote.ID = inserted.ID
+	quote.ExpiresAt = inserted.ExpiresAt
+
+	return
+}
+
+// CreateTransactionWithQuote stores the order locked to the rate of the quote
+// instead of the rate of the transaction date. The order is made in the quote
+// currency for the converted amount, used when transaction_value is zero and
+// there are no items; any other value, or items total, is rejected. Items and
+// customerID are stored as in CreateTransactionWithItems.
+func (c Checkout) CreateTransactionWithQuote(description string, transaction_date time.Time, transaction_value decimal.Decimal, transaction_currency string, quoteID int64, items []TransactionItem, customerID int64) (ID int64, err error) {
+	err = c.ValidateDescription(description)
+	if err != nil {
+		return
+	}
+
+	quote, err := c.getQuote(quoteID)
+	if err != nil {
+		return
+	}
+
+	if coreError.StringIsNotEmpty(transaction_currency) {
+		orderCurrency, errResolve := currency.ResolveCode(transaction_currency)
+		if errResolve != nil {
+			err = errResolve
+			return
+		}
+
+		if orderCurrency.Code != quote.Currency {
+			err = coreError.New("error.quote.currency.mismatch", coreError.ConcatenateStrings(orderCurrency.Code, " <> ", quote.Currency))
+			return
+		}
+	}
+
+	transaction_value, items, err = c.prepareItems(transaction_value, items)
+	if err != nil {
+		return
+	}
+
+	if transaction_value.IsZero() {
+		transaction_value = quote.ConvertedAmount
+	}
+
+	err = c.ValidateTrasactionValue(transaction_value)
+	if err != nil {
+		return
+	}
+
+	transaction_value = money.RoundMoney(transaction_value, money.HalfUp)
+
+	if !transaction_value.Equal(quote.ConvertedAmount) {
+		err = coreError.New("error.quote.amount.mismatch", coreError.ConcatenateStrings(transaction_value.String(), " <> ", quote.ConvertedAmount.String()))
+		return
+	}
+
+	params := sqlc.InsertTransactionParams{
+		Description:          description,
+		TransactionDate:      transaction_date,
+		TransactionValue:     transaction_value,
+		Currency:             quote.Currency,
+		UsdExchangeRate:      quote.ExchangeRate,
+		TransactionValueUsd:  money.RoundMoney(transaction_value.Div(quote.ExchangeRate), money.HalfEven),
+		QuoteID:              quote.ID,
+		UsdRateEffectiveDate: quote.EffectiveDate,
+		CustomerID:           customerID,
+	}
+
+	setItems(&params, items)
+
+	return c.insertTransaction(params)
+}
+
+/*****
+funcs for creations
+******/
+
+/*****
+funcs for gets
+******/
+
+// getQuote returns the quote while it can still lock an order: it must exist,
+// not be expired and not be used by another order.
+func (Checkout) getQuote(quoteID int64) (quote sqlc.SelectQuoteByIDRow, err error) {
+	quote, err = database.DB_QUERIER.SelectQuoteByID(context.Background(), quoteID)
+	if errors.Is(err, sql.ErrNoRows) {
+		err = coreError.New("error.quote.not.found", strconv.FormatInt(quoteID, 10))
+		return
+	}
+
+	if err != nil {
+		err = database.Utils{}.CoreErrorDatabase(err)
+		return
+	}
+
+	if quote.Used {
+		err = coreError.New("error.quote.already.used", strconv.FormatInt(quoteID, 10))
+		return
+	}
+
+	if quote.Expired {
+		err = coreError.New("error.quote.expired", quote.ExpiresAt.Format(time.RFC3339))
+		return
+	}
+
+	return
+}
+
+/*****
+funcs for gets
+******/
+
+/*****
+other funcs
+******/
+
+func quoteTTL() time.Duration {
+	return durationFromConfig("QUOTE_TTL", config.QUOTE_TTL, defaultQuoteTTL)
+}
+
+/*****
+other funcs
+******/
@@ -0,0 +1,135 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/luancpereira/APICheckout/core/database"
+	"github.com/luancpereira/APICheckout/core/database/sqlc"
+	coreErrors "github.com/luancpereira/APICheckout/core/errors"
+	"github.com/luancpereira/APICheckout/core/service"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateQuote(t *testing.T) {
+	var saved sqlc.InsertQuoteParams
+	database.DB_QUERIER = MockQuerier{QuoteSaved: &saved}
+
+	checkout := service.Checkout{
+		RateProvider: service.FakeRateProvider{
+			Records: []service.Record{
+				{Country: "Brazil", CountryCurrencyDesc: "Brazil-Real", EffectiveDate: time.Now().AddDate(0, 0, -3).Format("2006-01-02"), ExchangeRate: "6.192"},
+			},
+		},
+	}
+
+	t.Run("Deve converter o valor em dólar com a cotação vigente", func(t *testing.T) {
+		quote, err := checkout.CreateQuote(decimal.RequireFromString("10.005"), "brl")
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(7), quote.ID)
+		assert.Equal(t, "BRL", quote.Currency)
+		assert.Equal(t, "10.01", quote.Amount.String())
+		assert.Equal(t, "6.192", quote.ExchangeRate.String())
+		assert.Equal(t, "61.98", quote.ConvertedAmount.String())
+		assert.Equal(t, "61.98", saved.ConvertedAmount.String())
+		assert.Equal(t, int64(600), saved.TtlSeconds)
+		assert.True(t, quote.ExpiresAt.After(time.Now()))
+	})
+
+	t.Run("Deve retornar erro quando não houver cotação para a moeda", func(t *testing.T) {
+		_, err := checkout.CreateQuote(decimal.NewFromInt(10), "JPY")
+
+		coreErr, ok := err.(*coreErrors.CoreError)
+		assert.True(t, ok, "O erro retornado deve ser do tipo CoreError")
+		assert.Equal(t, "error.not.found.value.record", coreErr.Key)
+	})
+}
+
+func TestCreateTransactionWithQuote(t *testing.T) {
+	quotes := map[int64]sqlc.SelectQuoteByIDRow{
+		1: {ID: 1, Currency: "BRL", Amount: decimal.RequireFromString("10"), ExchangeRate: decimal.RequireFromString("6.192"), ConvertedAmount: decimal.RequireFromString("61.92")},
+		2: {ID: 2, Currency: "BRL", Expired: true},
+		3: {ID: 3, Currency: "BRL", Used: true},
+	}
+	transactionDate := time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)
+
+	assertKey := func(t *testing.T, err error, key string) {
+		coreErr, ok := err.(*coreErrors.CoreError)
+		assert.True(t, ok, "O erro retornado deve ser do tipo CoreError")
+		assert.Equal(t, key, coreErr.Key)
+	}
+
+	t.Run("Deve travar o pedido na cotação", func(t *testing.T) {
+		var inserted sqlc.InsertTransactionParams
+		database.DB_QUERIER = MockQuerier{Inserted: &inserted, Quotes: quotes}
+
+		_, err := service.Checkout{}.CreateTransactionWithQuote("Pedido", transactionDate, decimal.Zero, "", 1, nil, 0)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "BRL", inserted.Currency)
+		assert.Equal(t, "61.92", inserted.TransactionValue.String())
+		assert.Equal(t, "6.192", inserted.UsdExchangeRate.String())
+		assert.Equal(t, "10", inserted.TransactionValueUsd.String())
+		assert.Equal(t, int64(1), inserted.QuoteID)
+	})
+
+	t.Run("Deve retornar erro para cotação inexistente, expirada ou usada", func(t *testing.T) {
+		var inserted sqlc.InsertTransactionParams
+		database.DB_QUERIER = MockQuerier{Inserted: &inserted, Quotes: quotes}
+
+		_, err := service.Checkout{}.CreateTransactionWithQuote("Pedido", transactionDate, decimal.Zero, "", 9, nil, 0)
+		assertKey(t, err, "error.quote.not.found")
+
+		_, err = service.Checkout{}.CreateTransactionWithQuote("Pedido", transactionDate, decimal.Zero, "", 2, nil, 0)
+		assertKey(t, err, "error.quote.expired")
+
+		_, err = service.Checkout{}.CreateTransactionWithQuote("Pedido", transactionDate, decimal.Zero, "", 3, nil, 0)
+		assertKey(t, err, "error.quote.already.used")
+	})
+
+	t.Run("Deve retornar erro quando a moeda do pedido for diferente da cotação", func(t *testing.T) {
+		var inserted sqlc.InsertTransactionParams
+		database.DB_QUERIER = MockQuerier{Inserted: &inserted, Quotes: quotes}
+
+		_, err := service.Checkout{}.CreateTransactionWithQuote("Pedido", transactionDate, decimal.NewFromInt(50), "CAD", 1, nil, 0)
+		assertKey(t, err, "error.quote.currency.mismatch")
+	})
+
+	t.Run("Deve retornar erro quando outro pedido usar a cotação ao mesmo tempo", func(t *testing.T) {
+		var inserted sqlc.InsertTransactionParams
+		database.DB_QUERIER = MockQuerier{Inserted: &inserted, Quotes: quotes, InsertErr: &pq.Error{Code: "23505"}}
+
+		_, err := service.Checkout{}.CreateTransactionWithQuote("Pedido", transactionDate, decimal.Zero, "BRL", 1, nil, 0)
+		assertKey(t, err, "error.quote.already.used")
+	})
+
+	t.Run("Deve aceitar itens que somam o valor convertido", func(t *testing.T) {
+		var inserted sqlc.InsertTransactionParams
+		database.DB_QUERIER = MockQuerier{Inserted: &inserted, Quotes: quotes}
+
+		items := []service.TransactionItem{{Sku: "CAM-01", Name: "Camiseta", Quantity: 6, UnitPrice: decimal.RequireFromString("10.32")}}
+		_, err := service.Checkout{}.CreateTransactionWithQuote("Pedido", transactionDate, decimal.Zero, "", 1, items, 0)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "61.92", inserted.TransactionValue.String())
+		assert.Equal(t, "10", inserted.TransactionValueUsd.String())
+		assert.Equal(t, []string{"CAM-01"}, inserted.ItemSkus)
+	})
+
+	t.Run("Deve retornar erro quando o valor ou os itens forem diferentes do valor convertido", func(t *testing.T) {
+		var inserted sqlc.InsertTransactionParams
+		database.DB_QUERIER = MockQuerier{Inserted: &inserted, Quotes: quotes}
+
+		_, err := service.Checkout{}.CreateTransactionWithQuote("Pedido", transactionDate, decimal.NewFromInt(50), "", 1, nil, 0)
+		assertKey(t, err, "error.quote.amount.mismatch")
+
+		items := []service.TransactionItem{{Sku: "CAM-01", Name: "Camiseta", Quantity: 3, UnitPrice: decimal.RequireFromString("10.32")}}
+		_, err = service.Checkout{}.CreateTransactionWithQuote("Pedido", transactionDate, decimal.Zero, "", 1, items, 0)
+		assertKey(t, err, "error.quote.amount.mismatch")
+
+		assert.Empty(t, inserted.Description)
+	})
+}
@@ -0,0 +1,163 @@
+package service
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/luancpereira/APICheckout/core/config"
+	coreError "github.com/luancpereira/APICheckout/core/errors"
+)
+
+type RateSelection string
+
+const (
+	RATE_SELECTION_ON_OR_BEFORE    RateSelection = "on-or-before"
+	RATE_SELECTION_STRICTLY_BEFORE RateSelection = "strictly-before"
+	RATE_SELECTION_NEAREST         RateSelection = "nearest"
+	RATE_SELECTION_EXACT           RateSelection = "exact"
+
+	defaultRatePolicyMaxAgeDays = 182
+)
+
+// RatePolicy defines which Treasury record applies to a transaction date:
+// Selection decides on which side of the date the effective date may fall and
+// MaxAge how far from it, where zero means no limit.
+type RatePolicy struct {
+	Selection RateSelection
+	MaxAge    time.Duration
+}
+
+// deploymentRatePolicy is the policy of the deployment, on-or-before within
+// 182 days until LoadRatePolicy reads the configuration.
+var deploymentRatePolicy = RatePolicy{
+	Selection: RATE_SELECTION_ON_OR_BEFORE,
+	MaxAge:    defaultRatePolicyMaxAgeDays * 24 * time.Hour,
+}
+
+// LoadRatePolicy sets the policy of the deployment from RATE_POLICY and
+// RATE_POLICY_MAX_AGE_DAYS. It runs once at startup, which fails on an
+// invalid configuration instead of serving with another policy.
+func LoadRatePolicy() (err error) {
+	policy := RatePolicy{
+		Selection: RATE_SELECTION_ON_OR_BEFORE,
+		MaxAge:    defaultRatePolicyMaxAgeDays * 24 * time.Hour,
+	}
+
+	policy, err = policy.Override(config.RATE_POLICY, config.RATE_POLICY_MAX_AGE_DAYS)
+	if err != nil {
+		return
+	}
+
+	deploymentRatePolicy = policy
+
+	return
+}
+
+// DefaultRatePolicy is the policy of the deployment.
+func DefaultRatePolicy() RatePolicy {
+	return deploymentRatePolicy
+}
+
+// Override returns a copy of the policy with the selection and the maximum age
+// in days replaced by the values that are not empty.
+func (p RatePolicy) Override(selection, maxAgeDays string) (policy RatePolicy, err error) {
+	policy = p
+
+	if coreError.StringIsNotEmpty(selection) {
+		policy.Selection = RateSelection(strings.ToLower(strings.TrimSpace(selection)))
+
+		switch policy.Selection {
+		case RATE_SELECTION_ON_OR_BEFORE, RATE_SELECTION_STRICTLY_BEFORE, RATE_SELECTION_NEAREST, RATE_SELECTION_EXACT:
+		default:
+			err = coreError.New("error.rate.policy.invalid", selection)
+			return
+		}
+	}
+
+	if coreError.StringIsNotEmpty(maxAgeDays) {
+		days, errParse := strconv.Atoi(strings.TrimSpace(maxAgeDays))
+		if errParse != nil || days < 0 {
+			err = coreError.New("error.rate.policy.invalid", maxAgeDays)
+			return
+		}
+
+		policy.MaxAge = time.Duration(days) * 24 * time.Hour
+	}
+
+	return
+}
+
+// Window returns the range of effective dates the policy can select for the
+// target date, used by the providers to narrow their queries.
+func (p RatePolicy) Window(targetDate time.Time) (from, to time.Time) {
+	targetDay := truncateToDay(targetDate)
+	from, to = targetDay, targetDay
+
+	if p.Selection == RATE_SELECTION_EXACT {
+		return
+	}
+
+	if p.MaxAge > 0 {
+		from = targetDay.Add(-p.MaxAge)
+	} else {
+		from = time.Time{}
+	}
+
+	if p.Selection == RATE_SELECTION_NEAREST {
+		if p.MaxAge > 0 {
+			to = targetDay.Add(p.MaxAge)
+		} else {
+			to = time.Now().UTC().AddDate(1, 0, 0)
+		}
+	}
+
+	return
+}
+
+// accepts tells whether a record whose effective date is diff away from the
+// target day, negative when it is before, can be selected.
+func (p RatePolicy) accepts(diff time.Duration) bool {
+	if p.MaxAge > 0 && (diff > p.MaxAge || -diff > p.MaxAge) {
+		return false
+	}
+
+	switch p.Selection {
+	case RATE_SELECTION_STRICTLY_BEFORE:
+		return diff < 0
+	case RATE_SELECTION_NEAREST:
+		return true
+	case RATE_SELECTION_EXACT:
+		return diff == 0
+	default:
+		return diff <= 0
+	}
+}
+
+// firstDayOf returns the first day the policy resolves to the record with
+// effectiveDate, in the 2006-01-02 layout, when that record applies to a later
+// day: every day in between resolves to it as well, since no other record falls
+// there. Policies that may look past the day have no such period.
+func (p RatePolicy) firstDayOf(effectiveDate string) (day string, found bool) {
+	recordDate, err := time.Parse("2006-01-02", effectiveDate)
+	if err != nil {
+		return
+	}
+
+	switch p.Selection {
+	case RATE_SELECTION_ON_OR_BEFORE:
+		return effectiveDate, true
+	case RATE_SELECTION_STRICTLY_BEFORE:
+		return recordDate.AddDate(0, 0, 1).Format("2006-01-02"), true
+	default:
+		return
+	}
+}
+
+func (p RatePolicy) String() string {
+	return coreError.ConcatenateStrings(string(p.Selection), ":", strconv.FormatInt(int64(p.MaxAge/time.Hour), 10), "h")
+}
+
+func truncateToDay(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+}
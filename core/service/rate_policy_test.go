@@ -0,0 +1,128 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/luancpereira/APICheckout/core/config"
+	coreErrors "github.com/luancpereira/APICheckout/core/errors"
+	"github.com/luancpereira/APICheckout/core/service"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindRegistryWithPolicy(t *testing.T) {
+	records := []service.Record{
+		{EffectiveDate: "2024-12-31", ExchangeRate: "6.192"},
+		{EffectiveDate: "2025-01-06", ExchangeRate: "6.200"},
+		{EffectiveDate: "2025-01-08", ExchangeRate: "6.210"},
+	}
+	week := 7 * 24 * time.Hour
+
+	t.Run("Deve incluir o registro da própria data na política on-or-before", func(t *testing.T) {
+		record, err := service.FindRegistryWithPolicy(records, time.Date(2025, 1, 6, 15, 30, 0, 0, time.UTC), service.RatePolicy{Selection: service.RATE_SELECTION_ON_OR_BEFORE})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "2025-01-06", record.EffectiveDate)
+	})
+
+	t.Run("Deve ignorar o registro da própria data na política strictly-before", func(t *testing.T) {
+		record, err := service.FindRegistryWithPolicy(records, time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC), service.RatePolicy{Selection: service.RATE_SELECTION_STRICTLY_BEFORE})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "2024-12-31", record.EffectiveDate)
+	})
+
+	t.Run("Deve escolher o registro mais próximo em qualquer direção na política nearest", func(t *testing.T) {
+		record, err := service.FindRegistryWithPolicy(records, time.Date(2025, 1, 9, 0, 0, 0, 0, time.UTC), service.RatePolicy{Selection: service.RATE_SELECTION_NEAREST})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "2025-01-08", record.EffectiveDate)
+	})
+
+	t.Run("Deve preferir o registro anterior em caso de empate na política nearest", func(t *testing.T) {
+		record, err := service.FindRegistryWithPolicy(records, time.Date(2025, 1, 7, 0, 0, 0, 0, time.UTC), service.RatePolicy{Selection: service.RATE_SELECTION_NEAREST})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "2025-01-06", record.EffectiveDate)
+	})
+
+	t.Run("Deve exigir a mesma data na política exact", func(t *testing.T) {
+		_, err := service.FindRegistryWithPolicy(records, time.Date(2025, 1, 7, 0, 0, 0, 0, time.UTC), service.RatePolicy{Selection: service.RATE_SELECTION_EXACT})
+
+		coreErr, ok := err.(*coreErrors.CoreError)
+		assert.True(t, ok, "O erro retornado deve ser do tipo CoreError")
+		assert.Equal(t, "error.not.found.value.record", coreErr.Key)
+	})
+
+	t.Run("Deve respeitar a idade máxima do registro", func(t *testing.T) {
+		_, err := service.FindRegistryWithPolicy(records, time.Date(2025, 1, 5, 0, 0, 0, 0, time.UTC), service.RatePolicy{Selection: service.RATE_SELECTION_ON_OR_BEFORE, MaxAge: 4 * 24 * time.Hour})
+		assert.Error(t, err)
+
+		record, err := service.FindRegistryWithPolicy(records, time.Date(2025, 1, 5, 0, 0, 0, 0, time.UTC), service.RatePolicy{Selection: service.RATE_SELECTION_ON_OR_BEFORE, MaxAge: week})
+		assert.NoError(t, err)
+		assert.Equal(t, "2024-12-31", record.EffectiveDate)
+	})
+}
+
+func TestRatePolicyOverride(t *testing.T) {
+	policy := service.RatePolicy{Selection: service.RATE_SELECTION_ON_OR_BEFORE, MaxAge: 182 * 24 * time.Hour}
+
+	t.Run("Deve substituir apenas os valores informados", func(t *testing.T) {
+		overridden, err := policy.Override(" Nearest ", "")
+
+		assert.NoError(t, err)
+		assert.Equal(t, service.RatePolicy{Selection: service.RATE_SELECTION_NEAREST, MaxAge: policy.MaxAge}, overridden)
+
+		overridden, err = policy.Override("", "0")
+
+		assert.NoError(t, err)
+		assert.Equal(t, service.RatePolicy{Selection: service.RATE_SELECTION_ON_OR_BEFORE}, overridden)
+	})
+
+	t.Run("Deve retornar erro para política ou idade inválida", func(t *testing.T) {
+		for _, values := range [][2]string{{"latest", ""}, {"", "-1"}, {"", "abc"}} {
+			_, err := policy.Override(values[0], values[1])
+
+			coreErr, ok := err.(*coreErrors.CoreError)
+			assert.True(t, ok, "O erro retornado deve ser do tipo CoreError")
+			assert.Equal(t, "error.rate.policy.invalid", coreErr.Key)
+		}
+	})
+
+	t.Run("Deve limitar a janela de busca conforme a política", func(t *testing.T) {
+		targetDate := time.Date(2025, 1, 6, 10, 0, 0, 0, time.UTC)
+
+		from, to := service.RatePolicy{Selection: service.RATE_SELECTION_NEAREST, MaxAge: 24 * time.Hour}.Window(targetDate)
+		assert.Equal(t, time.Date(2025, 1, 5, 0, 0, 0, 0, time.UTC), from)
+		assert.Equal(t, time.Date(2025, 1, 7, 0, 0, 0, 0, time.UTC), to)
+
+		from, to = service.RatePolicy{Selection: service.RATE_SELECTION_EXACT}.Window(targetDate)
+		assert.Equal(t, time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC), from)
+		assert.Equal(t, from, to)
+	})
+}
+
+func TestLoadRatePolicy(t *testing.T) {
+	defer func() {
+		config.RATE_POLICY, config.RATE_POLICY_MAX_AGE_DAYS = "", ""
+		service.LoadRatePolicy()
+	}()
+
+	t.Run("Deve usar a política configurada", func(t *testing.T) {
+		config.RATE_POLICY, config.RATE_POLICY_MAX_AGE_DAYS = "nearest", "30"
+
+		assert.NoError(t, service.LoadRatePolicy())
+		assert.Equal(t, service.RatePolicy{Selection: service.RATE_SELECTION_NEAREST, MaxAge: 30 * 24 * time.Hour}, service.DefaultRatePolicy())
+	})
+
+	t.Run("Deve retornar erro e manter a política para configuração inválida", func(t *testing.T) {
+		config.RATE_POLICY, config.RATE_POLICY_MAX_AGE_DAYS = "latest", ""
+
+		err := service.LoadRatePolicy()
+
+		coreErr, ok := err.(*coreErrors.CoreError)
+		assert.True(t, ok, "O erro retornado deve ser do tipo CoreError")
+		assert.Equal(t, "error.rate.policy.invalid", coreErr.Key)
+		assert.Equal(t, service.RATE_SELECTION_NEAREST, service.DefaultRatePolicy().Selection)
+	})
+}
@@ -0,0 +1,139 @@
+package service
+
+import (
+	"strings"
+	"time"
+
+	"github.com/luancpereira/APICheckout/core/currency"
+	coreError "github.com/luancpereira/APICheckout/core/errors"
+	"github.com/shopspring/decimal"
+)
+
+type Rates struct {
+	Provider ExchangeRateProvider
+}
+
+/*****
+funcs for gets
+******/
+
+// GetCurrencies lists the currencies of the configured rate provider with
+// their latest rate. filters["name"] keeps only the currencies whose country,
+// currency or country_currency_desc starts with the given prefix.
+func (r Rates) GetCurrencies(filters map[string]string) (models []CurrencyDetail, err error) {
+	rows, err := r.Provider.FindCurrencies(strings.TrimSpace(filters["name"]))
+	if err != nil {
+		return
+	}
+
+	for _, row := range rows {
+		exchangeRate, errParse := decimal.NewFromString(row.ExchangeRate)
+		if errParse != nil {
+			err = coreError.New("error.rate.invalid", coreError.ConcatenateStrings(row.CountryCurrencyDesc, " ", row.ExchangeRate))
+			return
+		}
+
+		model := CurrencyDetail{
+			Country:             row.Country,
+			Currency:            row.Currency,
+			CountryCurrencyDesc: row.CountryCurrencyDesc,
+			EffectiveDate:       row.EffectiveDate,
+			ExchangeRate:        exchangeRate,
+		}
+
+		if registered, ok := currency.Registered(row.CountryCurrencyDesc); ok {
+			model.Code = registered.Code
+		}
+
+		models = append(models, model)
+	}
+
+	return
+}
+
+// GetHistory returns the records of the configured rate provider for the
+// currency with an effective date between from and to, both optional and in
+// the 2006-01-02 layout, sorted by effective date and paginated by limit, at
+// least 1, and offset.
+func (r Rates) GetHistory(country, from, to string, limit, offset int64) (models []RateHistory, total int64, err error) {
+	target, err := currency.Resolve(country)
+	if err != nil {
+		return
+	}
+
+	if coreError.StringIsNotEmpty(from) {
+		_, err = time.Parse("2006-01-02", from)
+		if err != nil {
+			err = coreError.New("error.request.query.param.invalid", "from")
+			return
+		}
+	}
+
+	if coreError.StringIsNotEmpty(to) {
+		_, err = time.Parse("2006-01-02", to)
+		if err != nil {
+			err = coreError.New("error.request.query.param.invalid", "to")
+			return
+		}
+	}
+
+	if coreError.StringIsNotEmpty(from) && coreError.StringIsNotEmpty(to) && from > to {
+		err = coreError.New("error.request.query.param.invalid", "from")
+		return
+	}
+
+	if limit < 1 {
+		err = coreError.New("error.request.query.param.invalid", "limit")
+		return
+	}
+
+	records, total, err := r.Provider.FindHistory(target.Key, from, to, limit, max(offset, 0))
+	if err != nil {
+		return
+	}
+
+	for _, record := range records {
+		exchangeRate, errParse := decimal.NewFromString(record.ExchangeRate)
+		if errParse != nil {
+			err = coreError.New("error.rate.invalid", coreError.ConcatenateStrings(record.CountryCurrencyDesc, " ", record.ExchangeRate))
+			return
+		}
+
+		models = append(models, RateHistory{
+			CountryCurrencyDesc: record.CountryCurrencyDesc,
+			RecordDate:          record.RecordDate,
+			EffectiveDate:       record.EffectiveDate,
+			ExchangeRate:        exchangeRate,
+		})
+	}
+
+	return
+}
+
+/*****
+funcs for gets
+******/
+
+/*****
+other funcs
+******/
+
+type CurrencyDetail struct {
+	Country             string
+	Currency            string
+	CountryCurrencyDesc string
+	Code                string
+	EffectiveDate       string
+	ExchangeRate        decimal.Decimal
+}
+
+type RateHistory struct {
+	CountryCurrencyDesc string
+	RecordDate          string
+	EffectiveDate       string
+	ExchangeRate        decimal.Decimal
+}
+
+/*****
+other funcs
+******/
@@ -0,0 +1,221 @@
+package service_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/luancpereira/APICheckout/core/database"
+	"github.com/luancpereira/APICheckout/core/database/sqlc"
+	coreErrors "github.com/luancpereira/APICheckout/core/errors"
+	"github.com/luancpereira/APICheckout/core/service"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetCurrencies(t *testing.T) {
+	var name string
+	database.DB_QUERIER = MockQuerier{
+		Currencies: []sqlc.SelectCurrenciesRow{
+			{Country: "Brazil", Currency: "Real", CountryCurrencyDesc: "Brazil-Real", EffectiveDate: "2024-12-31", ExchangeRate: "6.192"},
+			{Country: "Bhutan", Currency: "Ngultrum", CountryCurrencyDesc: "Bhutan-Ngultrum", EffectiveDate: "2024-12-31", ExchangeRate: "85.5"},
+		},
+		CurrenciesName: &name,
+	}
+
+	t.Run("Deve retornar a última cotação e o código ISO quando conhecido", func(t *testing.T) {
+		models, err := service.Rates{Provider: service.DatabaseRateProvider{}}.GetCurrencies(map[string]string{"name": "B"})
+
+		assert.NoError(t, err)
+		assert.Len(t, models, 2)
+		assert.Equal(t, "BRL", models[0].Code)
+		assert.Equal(t, "6.192", models[0].ExchangeRate.String())
+		assert.Equal(t, "2024-12-31", models[0].EffectiveDate)
+		assert.Empty(t, models[1].Code)
+	})
+
+	t.Run("Deve escapar os curingas do prefixo", func(t *testing.T) {
+		_, err := service.Rates{Provider: service.DatabaseRateProvider{}}.GetCurrencies(map[string]string{"name": `B%_\`})
+
+		assert.NoError(t, err)
+		assert.Equal(t, `B\%\_\\`, name)
+	})
+
+	t.Run("Deve listar as moedas dos provedores sem banco", func(t *testing.T) {
+		provider := service.FakeRateProvider{
+			Records: []service.Record{
+				{Country: "Brazil", Currency: "Real", CountryCurrencyDesc: "Brazil-Real", EffectiveDate: "2024-09-30", ExchangeRate: "5.434"},
+				{Country: "Brazil", Currency: "Real", CountryCurrencyDesc: "Brazil-Real", EffectiveDate: "2024-12-31", ExchangeRate: "6.192"},
+				{Country: "Afghanistan", Currency: "Afghani", CountryCurrencyDesc: "Afghanistan-Afghani", EffectiveDate: "2024-12-31", ExchangeRate: "70.5"},
+				{Country: "Canada", Currency: "Dollar", CountryCurrencyDesc: "Canada-Dollar", EffectiveDate: "2024-12-31", ExchangeRate: "1.44"},
+			},
+		}
+
+		models, err := service.Rates{Provider: provider}.GetCurrencies(map[string]string{"name": "a"})
+
+		assert.NoError(t, err)
+		assert.Len(t, models, 1)
+		assert.Equal(t, "Afghanistan-Afghani", models[0].CountryCurrencyDesc)
+
+		models, err = service.Rates{Provider: provider}.GetCurrencies(map[string]string{})
+
+		assert.NoError(t, err)
+		assert.Len(t, models, 3)
+		assert.Equal(t, "Brazil-Real", models[1].CountryCurrencyDesc)
+		assert.Equal(t, "6.192", models[1].ExchangeRate.String())
+	})
+
+	t.Run("Deve listar as moedas publicadas recentemente pelo Tesouro", func(t *testing.T) {
+		var filter, sort string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			filter = r.URL.Query().Get("filter")
+			sort = r.URL.Query().Get("sort")
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"data": [
+				{"country": "Brazil", "currency": "Real", "country_currency_desc": "Brazil-Real", "effective_date": "2024-12-31", "exchange_rate": "6.192"},
+				{"country": "Brazil", "currency": "Real", "country_currency_desc": "Brazil-Real", "effective_date": "2024-09-30", "exchange_rate": "5.434"}
+			], "meta": {"count": 2, "total-pages": 1}}`))
+		}))
+		defer server.Close()
+
+		models, err := service.Rates{Provider: service.TreasuryRateProvider{URL: server.URL}}.GetCurrencies(map[string]string{"name": "bra"})
+
+		assert.NoError(t, err)
+		assert.Contains(t, filter, "effective_date:gte:")
+		assert.Equal(t, "-effective_date", sort)
+		assert.Len(t, models, 1)
+		assert.Equal(t, "6.192", models[0].ExchangeRate.String())
+	})
+
+	t.Run("Deve retornar erro para cotação inválida", func(t *testing.T) {
+		provider := service.FakeRateProvider{
+			Records: []service.Record{{Country: "Brazil", Currency: "Real", CountryCurrencyDesc: "Brazil-Real", EffectiveDate: "2024-12-31", ExchangeRate: "n/a"}},
+		}
+
+		_, err := service.Rates{Provider: provider}.GetCurrencies(map[string]string{})
+
+		coreErr, ok := err.(*coreErrors.CoreError)
+		assert.True(t, ok, "O erro retornado deve ser do tipo CoreError")
+		assert.Equal(t, "error.rate.invalid", coreErr.Key)
+	})
+}
+
+func TestGetHistory(t *testing.T) {
+	records := []string{
+		`{"country_currency_desc": "Brazil-Real", "effective_date": "2024-03-31", "exchange_rate": "4.994"}`,
+		`{"country_currency_desc": "Brazil-Real", "effective_date": "2024-06-30", "exchange_rate": "5.548"}`,
+		`{"country_currency_desc": "Brazil-Real", "effective_date": "2024-09-30", "exchange_rate": "5.434"}`,
+		`{"country_currency_desc": "Brazil-Real", "effective_date": "2024-12-31", "exchange_rate": "6.192"}`,
+	}
+
+	var filter, sort string
+	var pages []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		filter = query.Get("filter")
+		sort = query.Get("sort")
+		pages = append(pages, query.Get("page[number]")+"/"+query.Get("page[size]"))
+
+		number, _ := strconv.Atoi(query.Get("page[number]"))
+		size, _ := strconv.Atoi(query.Get("page[size]"))
+		start := min((number-1)*size, len(records))
+		end := min(start+size, len(records))
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(fmt.Sprintf(`{"data": [%s], "meta": {"count": %d, "total-count": %d}}`, strings.Join(records[start:end], ","), end-start, len(records))))
+	}))
+	defer server.Close()
+
+	rates := service.Rates{Provider: service.TreasuryRateProvider{URL: server.URL}}
+
+	t.Run("Deve pedir ao Tesouro somente a página ordenada", func(t *testing.T) {
+		pages = nil
+		models, total, err := rates.GetHistory("BRL", "2024-01-01", "2024-12-31", 2, 2)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "country_currency_desc:eq:Brazil-Real,effective_date:gte:2024-01-01,effective_date:lte:2024-12-31", filter)
+		assert.Equal(t, "effective_date", sort)
+		assert.Equal(t, []string{"2/2"}, pages)
+		assert.Equal(t, int64(4), total)
+		assert.Len(t, models, 2)
+		assert.Equal(t, "2024-09-30", models[0].EffectiveDate)
+		assert.Equal(t, "6.192", models[1].ExchangeRate.String())
+	})
+
+	t.Run("Deve juntar duas páginas quando o offset não for múltiplo do limite", func(t *testing.T) {
+		pages = nil
+		models, _, err := rates.GetHistory("BRL", "", "", 2, 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"1/2", "2/2"}, pages)
+		assert.Len(t, models, 2)
+		assert.Equal(t, "2024-06-30", models[0].EffectiveDate)
+		assert.Equal(t, "2024-09-30", models[1].EffectiveDate)
+	})
+
+	t.Run("Deve retornar lista vazia quando o offset passar do total", func(t *testing.T) {
+		models, total, err := rates.GetHistory("BRL", "", "", 10, 10)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(4), total)
+		assert.Empty(t, models)
+	})
+
+	t.Run("Deve retornar erro para data inválida", func(t *testing.T) {
+		_, _, err := rates.GetHistory("BRL", "31/12/2024", "", 10, 0)
+
+		coreErr, ok := err.(*coreErrors.CoreError)
+		assert.True(t, ok, "O erro retornado deve ser do tipo CoreError")
+		assert.Equal(t, "error.request.query.param.invalid", coreErr.Key)
+	})
+
+	t.Run("Deve retornar erro para limite menor que 1", func(t *testing.T) {
+		for _, limit := range []int64{0, -1} {
+			pages = nil
+			_, _, err := rates.GetHistory("BRL", "", "", limit, 0)
+
+			coreErr, ok := err.(*coreErrors.CoreError)
+			assert.True(t, ok, "O erro retornado deve ser do tipo CoreError")
+			assert.Equal(t, "error.request.query.param.invalid", coreErr.Key)
+			assert.Empty(t, pages)
+		}
+	})
+
+	t.Run("Deve consultar o banco com o provedor do banco", func(t *testing.T) {
+		var params sqlc.SelectRatesOfExchangeHistoryParams
+		database.DB_QUERIER = MockQuerier{
+			RateHistory: []sqlc.SelectRatesOfExchangeHistoryRow{
+				{CountryCurrencyDesc: "Brazil-Real", RecordDate: "2024-09-30", EffectiveDate: "2024-09-30", ExchangeRate: "5.434"},
+				{CountryCurrencyDesc: "Brazil-Real", RecordDate: "2024-12-31", EffectiveDate: "2024-12-31", ExchangeRate: "6.192"},
+			},
+			HistoryParams: &params,
+		}
+
+		models, total, err := service.Rates{Provider: service.DatabaseRateProvider{}}.GetHistory("BRL", "2024-07-01", "", 1, 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, sqlc.SelectRatesOfExchangeHistoryParams{CountryCurrencyDesc: "Brazil-Real", EffectiveDateFrom: "2024-07-01", PageSize: 1, PageOffset: 1}, params)
+		assert.Equal(t, int64(2), total)
+		assert.Len(t, models, 1)
+		assert.Equal(t, "6.192", models[0].ExchangeRate.String())
+	})
+
+	t.Run("Deve filtrar e ordenar em memória com o provedor de arquivo", func(t *testing.T) {
+		provider := service.FileRateProvider{Records: []service.Record{
+			{CountryCurrencyDesc: "Brazil-Real", EffectiveDate: "2024-12-31", ExchangeRate: "6.192"},
+			{CountryCurrencyDesc: "Mexico-Peso", EffectiveDate: "2024-09-30", ExchangeRate: "19.6"},
+			{CountryCurrencyDesc: "Brazil-Real", EffectiveDate: "2024-03-31", ExchangeRate: "4.994"},
+			{CountryCurrencyDesc: "Brazil-Real", EffectiveDate: "2024-09-30", ExchangeRate: "5.434"},
+		}}
+
+		models, total, err := service.Rates{Provider: provider}.GetHistory("BRL", "2024-06-01", "", 10, 0)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), total)
+		assert.Len(t, models, 2)
+		assert.Equal(t, "2024-09-30", models[0].EffectiveDate)
+		assert.Equal(t, "2024-12-31", models[1].EffectiveDate)
+	})
+}
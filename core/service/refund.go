@@ -0,0 +1,300 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/luancpereira/APICheckout/core/currency"
+	"github.com/luancpereira/APICheckout/core/database"
+	"github.com/luancpereira/APICheckout/core/database/sqlc"
+	coreError "github.com/luancpereira/APICheckout/core/errors"
+	"github.com/luancpereira/APICheckout/core/money"
+	"github.com/shopspring/decimal"
+)
+
+// Refund is an amount returned of an order, in the order currency, converted
+// to the requested currency at the rates of the refund date.
+type Refund struct {
+	ID                            int64
+	Amount                        decimal.Decimal
+	Reason                        string
+	RefundDate                    time.Time
+	ExchangeRate                  decimal.Decimal
+	AmountConvertedToWishCurrency decimal.Decimal
+	Legs                          RateLegs
+	Stale                         bool
+}
+
+/*****
+funcs for creations
+******/
+
+// CreateRefund returns part of the value of a paid order. The amount is rounded
+// half-up to cents and, together with the previous refunds, cannot exceed the
+// value of the order. The refund date defaults to now, cannot precede the
+// order nor be after today and must have a rate for the order currency, so the
+// refund can always be converted.
+func (c Checkout) CreateRefund(orderID int64, amount decimal.Decimal, reason string, refund_date time.Time) (ID int64, err error) {
+	err = c.ValidateRefundReason(reason)
+	if err != nil {
+		return
+	}
+
+	err = c.ValidateTrasactionValue(amount)
+	if err != nil {
+		return
+	}
+
+	amount = money.RoundMoney(amount, money.HalfUp)
+
+	order, err := c.getTransaction(orderID, false)
+	if err != nil {
+		return
+	}
+
+	if refund_date.IsZero() {
+		refund_date = time.Now()
+	}
+
+	if truncateToDay(refund_date).Before(truncateToDay(order.TransactionDate)) {
+		err = coreError.New("error.refund.date.before.transaction", order.TransactionDate.Format("2006-01-02"))
+		return
+	}
+
+	if truncateToDay(refund_date).After(truncateToDay(time.Now())) {
+		err = coreError.New("error.refund.date.future", refund_date.Format("2006-01-02"))
+		return
+	}
+
+	orderCurrency, err := currency.Resolve(order.Currency)
+	if err != nil {
+		return
+	}
+
+	params := sqlc.InsertRefundParams{
+		OrderID:    order.ID,
+		Amount:     amount,
+		Reason:     reason,
+		RefundDate: refund_date,
+	}
+
+	// the order row stays locked until the transaction ends, so the refunds of
+	// an order are summed and inserted one at a time
+	err = database.DB_TRANSACTION(func(querier sqlc.Querier) (err error) {
+		locked, err := c.lockTransaction(querier, order.ID)
+		if err != nil {
+			return
+		}
+
+		if locked.Status != StatusPaid {
+			err = coreError.New("error.refund.status.invalid", locked.Status)
+			return
+		}
+
+		err = c.validateRefundable(querier, order.ID, locked.TransactionValue, amount)
+		if err != nil {
+			return
+		}
+
+		// the refunds are converted at the rate of their own date
+		_, _, err = c.getExchangeRate(refund_date, orderCurrency)
+		if err != nil {
+			return
+		}
+
+		ID, err = querier.InsertRefund(context.Background(), params)
+		if err != nil {
+			err = database.Utils{}.CoreErrorDatabase(err)
+			return
+		}
+
+		return
+	})
+
+	return
+}
+
+/*****
+funcs for creations
+******/
+
+/*****
+funcs for gets
+******/
+
+// getRefunds converts the refunds of the order to the target currency. Each
+// refund takes the rates of its own date, both of the order currency and of
+// the target, instead of the rate the order was created with.
+func (c Checkout) getRefunds(order sqlc.SelectTransactionByIDRow, target *conversionTarget) (refunds []Refund, refunded, refundedConverted decimal.Decimal, err error) {
+	rows, err := database.DB_QUERIER.SelectRefunds(context.Background(), order.ID)
+	if err != nil {
+		err = database.Utils{}.CoreErrorDatabase(err)
+		return
+	}
+
+	if len(rows) == 0 {
+		return
+	}
+
+	orderCurrency, err := currency.Resolve(order.Currency)
+	if err != nil {
+		return
+	}
+
+	source := newConversionTarget(orderCurrency, nil)
+
+	for _, row := range rows {
+		refund, errConvert := c.convertRefund(order.ID, order.Currency, source, target, row.Amount, row.RefundDate)
+		if errConvert != nil {
+			err = errConvert
+			return
+		}
+
+		refund.ID = row.ID
+		refund.Reason = row.Reason
+
+		refunded = refunded.Add(refund.Amount)
+		refundedConverted = refundedConverted.Add(refund.AmountConvertedToWishCurrency)
+		refunds = append(refunds, refund)
+	}
+
+	return
+}
+
+// convertRefund converts amount, refunded of an order in orderCurrency, to the
+// target at the rates of the refund date, the rate of the order currency being
+// resolved through source.
+func (c Checkout) convertRefund(orderID int64, orderCurrency string, source, target *conversionTarget, amount decimal.Decimal, refundDate time.Time) (refund Refund, err error) {
+	from := c.getConversionRate(source, refundDate)
+	if from.err != nil {
+		err = from.err
+		return
+	}
+
+	order := orderAmount{
+		id:                   orderID,
+		transactionDate:      refundDate,
+		currency:             orderCurrency,
+		value:                amount,
+		usdExchangeRate:      from.rate,
+		usdRateEffectiveDate: from.record.EffectiveDate,
+	}
+
+	refund = Refund{
+		Amount:                        amount,
+		RefundDate:                    refundDate,
+		ExchangeRate:                  decimal.NewFromInt(1),
+		AmountConvertedToWishCurrency: amount,
+	}
+
+	refund.Legs.From = from.leg(refundDate)
+	refund.Legs.To = refund.Legs.From
+
+	if target.currency.Code != orderCurrency {
+		to := c.getConversionRate(target, refundDate)
+		if to.err != nil {
+			err = to.err
+			return
+		}
+
+		refund.Legs.To = to.leg(refundDate)
+		refund.ExchangeRate, refund.AmountConvertedToWishCurrency = to.convert(order)
+	}
+
+	refund.Stale = refund.Legs.stale()
+
+	return
+}
+
+// lockTransaction reads the value and status of the order and locks its row
+// until the transaction of querier ends.
+func (Checkout) lockTransaction(querier sqlc.Querier, orderID int64) (locked sqlc.SelectTransactionForUpdateRow, err error) {
+	locked, err = querier.SelectTransactionForUpdate(context.Background(), orderID)
+	if errors.Is(err, sql.ErrNoRows) {
+		err = coreError.New("error.transaction.not.found", strconv.FormatInt(orderID, 10))
+		return
+	}
+
+	if err != nil {
+		err = database.Utils{}.CoreErrorDatabase(err)
+		return
+	}
+
+	return
+}
+
+func (Checkout) getRefundedTotal(querier sqlc.Querier, orderID int64) (refunded decimal.Decimal, err error) {
+	refunded, err = querier.SelectRefundsTotal(context.Background(), orderID)
+	if err != nil {
+		err = database.Utils{}.CoreErrorDatabase(err)
+		return
+	}
+
+	return
+}
+
+/*****
+funcs for gets
+******/
+
+/*****
+funcs for validations
+******/
+
+func (Checkout) ValidateRefundReason(reason string) (err error) {
+	if len(reason) == 0 {
+		err = coreError.New("error.refund.reason.empty")
+		return
+	}
+
+	if len(reason) > 255 {
+		err = coreError.New("error.refund.reason.too.long")
+		return
+	}
+
+	return
+}
+
+// validateRefundable checks that the refunds of the order plus amount do not
+// exceed value, the value of the order.
+func (c Checkout) validateRefundable(querier sqlc.Querier, orderID int64, value, amount decimal.Decimal) (err error) {
+	refunded, err := c.getRefundedTotal(querier, orderID)
+	if err != nil {
+		return
+	}
+
+	if refunded.Add(amount).GreaterThan(value) {
+		err = coreError.New("error.refund.exceeds.value", value.Sub(refunded).String())
+		return
+	}
+
+	return
+}
+
+// validateFullyRefunded locks the order and checks that its refunds cover its
+// whole value, which a paid order needs to move to refunded.
+func (c Checkout) validateFullyRefunded(querier sqlc.Querier, orderID int64) (err error) {
+	locked, err := c.lockTransaction(querier, orderID)
+	if err != nil {
+		return
+	}
+
+	refunded, err := c.getRefundedTotal(querier, orderID)
+	if err != nil {
+		return
+	}
+
+	if refunded.LessThan(locked.TransactionValue) {
+		err = coreError.New("error.status.refunds.incomplete", locked.TransactionValue.Sub(refunded).String())
+		return
+	}
+
+	return
+}
+
+/*****
+funcs for validations
+******/
@@ -0,0 +1,259 @@
+package service_test
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/luancpereira/APICheckout/core/database"
+	"github.com/luancpereira/APICheckout/core/database/sqlc"
+	coreErrors "github.com/luancpereira/APICheckout/core/errors"
+	"github.com/luancpereira/APICheckout/core/service"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateRefund(t *testing.T) {
+	var saved sqlc.InsertRefundParams
+	database.DB_QUERIER = MockQuerier{
+		Transactions: map[int64]sqlc.SelectTransactionByIDRow{
+			1: {ID: 1, Description: "Pedido", TransactionDate: time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC), TransactionValue: decimal.RequireFromString("61.92"), Currency: "BRL", UsdExchangeRate: decimal.RequireFromString("6.192"), Status: service.StatusPaid},
+			2: {ID: 2, Description: "Pedido pendente", TransactionDate: time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC), TransactionValue: decimal.RequireFromString("10"), Currency: "USD", UsdExchangeRate: decimal.RequireFromString("1"), Status: service.StatusPending},
+			3: {ID: 3, Description: "Pedido reembolsado", TransactionDate: time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC), TransactionValue: decimal.RequireFromString("10"), Currency: "USD", UsdExchangeRate: decimal.RequireFromString("1"), Status: service.StatusRefunded},
+		},
+		Refunds: map[int64][]sqlc.Refund{
+			1: {{ID: 1, OrderID: 1, Amount: decimal.RequireFromString("50")}},
+		},
+		RefundSaved: &saved,
+	}
+	checkout := service.Checkout{
+		RateProvider: service.FakeRateProvider{
+			Records: []service.Record{
+				{CountryCurrencyDesc: "Brazil-Real", EffectiveDate: "2024-12-31", ExchangeRate: "6.192"},
+			},
+		},
+	}
+	refundDate := time.Date(2025, 2, 10, 0, 0, 0, 0, time.UTC)
+
+	assertKey := func(t *testing.T, err error, key string) {
+		coreErr, ok := err.(*coreErrors.CoreError)
+		assert.True(t, ok, "O erro retornado deve ser do tipo CoreError")
+		assert.Equal(t, key, coreErr.Key)
+	}
+
+	t.Run("Deve registrar o reembolso até o valor restante do pedido", func(t *testing.T) {
+		ID, err := checkout.CreateRefund(1, decimal.RequireFromString("11.915"), "Produto com defeito", refundDate)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), ID)
+		assert.Equal(t, "11.92", saved.Amount.String())
+		assert.Equal(t, refundDate, saved.RefundDate)
+	})
+
+	t.Run("Deve retornar erro quando os reembolsos excederem o valor do pedido", func(t *testing.T) {
+		_, err := checkout.CreateRefund(1, decimal.RequireFromString("11.93"), "Produto com defeito", refundDate)
+
+		assertKey(t, err, "error.refund.exceeds.value")
+	})
+
+	t.Run("Deve permitir reembolso somente de pedido pago", func(t *testing.T) {
+		_, err := checkout.CreateRefund(2, decimal.NewFromInt(1), "Produto com defeito", refundDate)
+		assertKey(t, err, "error.refund.status.invalid")
+
+		_, err = checkout.CreateRefund(3, decimal.NewFromInt(1), "Produto com defeito", refundDate)
+		assertKey(t, err, "error.refund.status.invalid")
+	})
+
+	t.Run("Deve validar o motivo, o valor e a data do reembolso", func(t *testing.T) {
+		_, err := checkout.CreateRefund(1, decimal.NewFromInt(1), "", refundDate)
+		assertKey(t, err, "error.refund.reason.empty")
+
+		_, err = checkout.CreateRefund(1, decimal.NewFromInt(1), strings.Repeat("a", 256), refundDate)
+		assertKey(t, err, "error.refund.reason.too.long")
+
+		_, err = checkout.CreateRefund(1, decimal.Zero, "Produto com defeito", refundDate)
+		assertKey(t, err, "error.value.not.positive")
+
+		_, err = checkout.CreateRefund(1, decimal.NewFromInt(1), "Produto com defeito", time.Date(2025, 1, 5, 0, 0, 0, 0, time.UTC))
+		assertKey(t, err, "error.refund.date.before.transaction")
+
+		_, err = checkout.CreateRefund(1, decimal.NewFromInt(1), "Produto com defeito", time.Now().AddDate(0, 0, 1))
+		assertKey(t, err, "error.refund.date.future")
+	})
+
+	t.Run("Deve retornar erro sem registrar quando não houver cotação na data do reembolso", func(t *testing.T) {
+		saved = sqlc.InsertRefundParams{}
+		withoutRate := service.Checkout{RateProvider: service.FakeRateProvider{}}
+
+		_, err := withoutRate.CreateRefund(1, decimal.NewFromInt(1), "Produto com defeito", refundDate)
+
+		assertKey(t, err, "error.not.found.value.record")
+		assert.True(t, saved.Amount.IsZero())
+	})
+
+	t.Run("Deve retornar erro de não encontrado para pedido inexistente", func(t *testing.T) {
+		_, err := checkout.CreateRefund(9, decimal.NewFromInt(1), "Produto com defeito", refundDate)
+
+		assert.True(t, service.IsNotFound(err))
+	})
+}
+
+// LockingQuerier keeps the refunds in memory and, as Postgres does with the
+// order row, holds the lock taken by SelectTransactionForUpdate until the
+// transaction ends. Transactions wait for each other before taking the lock,
+// so they always run concurrently.
+type LockingQuerier struct {
+	MockQuerier
+	mu      *sync.Mutex
+	rowLock *sync.Mutex
+	arrived *sync.WaitGroup
+	refunds *[]decimal.Decimal
+	locked  *bool
+}
+
+func (m LockingQuerier) SelectTransactionForUpdate(ctx context.Context, id int64) (sqlc.SelectTransactionForUpdateRow, error) {
+	m.arrived.Done()
+	m.arrived.Wait()
+
+	m.rowLock.Lock()
+	*m.locked = true
+
+	return m.MockQuerier.SelectTransactionForUpdate(ctx, id)
+}
+
+func (m LockingQuerier) SelectRefundsTotal(ctx context.Context, orderID int64) (decimal.Decimal, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	total := decimal.Zero
+	for _, amount := range *m.refunds {
+		total = total.Add(amount)
+	}
+
+	return total, nil
+}
+
+func (m LockingQuerier) InsertRefund(ctx context.Context, arg sqlc.InsertRefundParams) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	*m.refunds = append(*m.refunds, arg.Amount)
+
+	return int64(len(*m.refunds)), nil
+}
+
+func TestCreateRefundConcurrently(t *testing.T) {
+	previous := database.DB_TRANSACTION
+	defer func() { database.DB_TRANSACTION = previous }()
+
+	var mu, rowLock sync.Mutex
+	var arrived sync.WaitGroup
+	var refunds []decimal.Decimal
+
+	mock := MockQuerier{
+		Transactions: map[int64]sqlc.SelectTransactionByIDRow{
+			1: {ID: 1, Description: "Pedido", TransactionDate: time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC), TransactionValue: decimal.RequireFromString("100"), Currency: "USD", UsdExchangeRate: decimal.RequireFromString("1"), Status: service.StatusPaid},
+		},
+	}
+	database.DB_QUERIER = mock
+
+	database.DB_TRANSACTION = func(fn func(querier sqlc.Querier) error) error {
+		locked := false
+		defer func() {
+			if locked {
+				rowLock.Unlock()
+			}
+		}()
+
+		return fn(LockingQuerier{MockQuerier: mock, mu: &mu, rowLock: &rowLock, arrived: &arrived, refunds: &refunds, locked: &locked})
+	}
+
+	errs := make([]error, 2)
+	arrived.Add(len(errs))
+
+	var done sync.WaitGroup
+	for i := range errs {
+		done.Add(1)
+		go func(i int) {
+			defer done.Done()
+			_, errs[i] = service.Checkout{}.CreateRefund(1, decimal.RequireFromString("60"), "Produto com defeito", time.Date(2025, 2, 10, 0, 0, 0, 0, time.UTC))
+		}(i)
+	}
+	done.Wait()
+
+	var failed []error
+	for _, err := range errs {
+		if err != nil {
+			failed = append(failed, err)
+		}
+	}
+
+	assert.Len(t, refunds, 1, "somente um dos reembolsos cabe no valor do pedido")
+	if assert.Len(t, failed, 1) {
+		coreErr, ok := failed[0].(*coreErrors.CoreError)
+		assert.True(t, ok, "O erro retornado deve ser do tipo CoreError")
+		assert.Equal(t, "error.refund.exceeds.value", coreErr.Key)
+	}
+}
+
+func TestGetByIDRefunds(t *testing.T) {
+	database.DB_QUERIER = MockQuerier{
+		Transactions: map[int64]sqlc.SelectTransactionByIDRow{
+			1: {ID: 1, Description: "Pedido em reais", TransactionDate: time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC), TransactionValue: decimal.RequireFromString("61.92"), Currency: "BRL", UsdExchangeRate: decimal.RequireFromString("6.192"), TransactionValueUsd: decimal.RequireFromString("10")},
+		},
+		Refunds: map[int64][]sqlc.Refund{
+			1: {{ID: 1, OrderID: 1, Amount: decimal.RequireFromString("10"), Reason: "Produto com defeito", RefundDate: time.Date(2025, 4, 2, 0, 0, 0, 0, time.UTC)}},
+		},
+	}
+
+	checkout := service.Checkout{
+		RateProvider: service.FakeRateProvider{
+			Records: []service.Record{
+				{Country: "Brazil", CountryCurrencyDesc: "Brazil-Real", EffectiveDate: "2024-12-31", ExchangeRate: "6.192"},
+				{Country: "Brazil", CountryCurrencyDesc: "Brazil-Real", EffectiveDate: "2025-03-31", ExchangeRate: "5.8"},
+				{Country: "Canada", CountryCurrencyDesc: "Canada-Dollar", EffectiveDate: "2024-12-31", ExchangeRate: "1.44"},
+				{Country: "Canada", CountryCurrencyDesc: "Canada-Dollar", EffectiveDate: "2025-03-31", ExchangeRate: "1.4"},
+			},
+		},
+	}
+
+	t.Run("Deve converter o reembolso com a cotação da data do reembolso", func(t *testing.T) {
+		transaction, err := checkout.GetByID(1, "canada", nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "14.4", transaction.TransactionValueConvertedToWishCurrency.String())
+		assert.Len(t, transaction.Refunds, 1)
+		assert.Equal(t, "0.24137931", transaction.Refunds[0].ExchangeRate.String())
+		assert.Equal(t, "2.41", transaction.Refunds[0].AmountConvertedToWishCurrency.String())
+		assert.Equal(t, "2025-03-31", transaction.Refunds[0].Legs.From.EffectiveDate)
+		assert.Equal(t, "10", transaction.RefundedValue.String())
+		assert.Equal(t, "51.92", transaction.NetValue.String())
+		assert.Equal(t, "11.99", transaction.NetValueConvertedToWishCurrency.String())
+	})
+
+	t.Run("Deve manter o valor do reembolso na moeda do pedido", func(t *testing.T) {
+		transaction, err := checkout.GetByID(1, "BRL", nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "1", transaction.Refunds[0].ExchangeRate.String())
+		assert.Equal(t, "10", transaction.Refunds[0].AmountConvertedToWishCurrency.String())
+		assert.Equal(t, "51.92", transaction.NetValueConvertedToWishCurrency.String())
+	})
+
+	t.Run("Deve retornar o valor líquido igual ao valor sem reembolsos", func(t *testing.T) {
+		database.DB_QUERIER = MockQuerier{
+			Transactions: map[int64]sqlc.SelectTransactionByIDRow{
+				2: {ID: 2, Description: "Pedido", TransactionValue: decimal.RequireFromString("10.5"), Currency: "USD", UsdExchangeRate: decimal.RequireFromString("1"), TransactionValueUsd: decimal.RequireFromString("10.5")},
+			},
+		}
+
+		transaction, err := checkout.GetByID(2, "USD", nil)
+
+		assert.NoError(t, err)
+		assert.Empty(t, transaction.Refunds)
+		assert.Equal(t, "10.5", transaction.NetValue.String())
+		assert.Equal(t, "10.5", transaction.NetValueConvertedToWishCurrency.String())
+	})
+}
@@ -0,0 +1,291 @@
+// Package servicetest holds the test doubles shared by the tests of the
+// service package and of the commands built on it.
+package servicetest
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/luancpereira/APICheckout/core/database/sqlc"
+	"github.com/luancpereira/APICheckout/core/service"
+	"github.com/shopspring/decimal"
+)
+
+// MockQuerier serves the queries from the fields set by each test and records
+// the writes in the pointer fields. Queries without a method here panic
+// through the embedded nil Querier.
+type MockQuerier struct {
+	sqlc.Querier
+	Transactions    map[int64]sqlc.SelectTransactionByIDRow
+	List            []sqlc.SelectTransactionsRow
+	Currencies      []sqlc.SelectCurrenciesRow
+	Inserted        *sqlc.InsertTransactionParams
+	Snapshots       map[int64][]sqlc.SelectConversionsRow
+	Saved           *[]sqlc.UpsertConversionParams
+	InsertErr       error
+	Quotes          map[int64]sqlc.SelectQuoteByIDRow
+	QuoteSaved      *sqlc.InsertQuoteParams
+	Updated         *sqlc.UpdateTransactionParams
+	Deleted         *[]int64
+	Refunds         map[int64][]sqlc.Refund
+	RefundSaved     *sqlc.InsertRefundParams
+	Transitioned    *sqlc.TransitionTransactionStatusParams
+	History         map[int64][]sqlc.OrderStatusHistory
+	ListParams      *sqlc.SelectTransactionsParams
+	Items           map[int64][]sqlc.OrderItem
+	Customers       map[int64]sqlc.Customer
+	CustomerSaved   *sqlc.InsertCustomerParams
+	CustomerUpdated *sqlc.UpdateCustomerParams
+	LastRecordDate  string
+	RatesOfExchange map[string]decimal.Decimal
+	Upserted        *[]sqlc.UpsertRateOfExchangeParams
+	CurrenciesName  *string
+	CustomerPages   *int
+	RateHistory     []sqlc.SelectRatesOfExchangeHistoryRow
+	HistoryParams   *sqlc.SelectRatesOfExchangeHistoryParams
+}
+
+func (m MockQuerier) SelectConversions(ctx context.Context, arg sqlc.SelectConversionsParams) ([]sqlc.SelectConversionsRow, error) {
+	var rows []sqlc.SelectConversionsRow
+	for _, orderID := range arg.OrderIds {
+		rows = append(rows, m.Snapshots[orderID]...)
+	}
+
+	return rows, nil
+}
+
+func (m MockQuerier) UpsertConversion(ctx context.Context, arg sqlc.UpsertConversionParams) error {
+	if m.Saved != nil {
+		*m.Saved = append(*m.Saved, arg)
+	}
+
+	return nil
+}
+
+func (m MockQuerier) InsertTransaction(ctx context.Context, arg sqlc.InsertTransactionParams) (int64, error) {
+	*m.Inserted = arg
+	return 1, m.InsertErr
+}
+
+func (m MockQuerier) InsertQuote(ctx context.Context, arg sqlc.InsertQuoteParams) (sqlc.InsertQuoteRow, error) {
+	*m.QuoteSaved = arg
+	return sqlc.InsertQuoteRow{ID: 7, ExpiresAt: time.Now().Add(time.Duration(arg.TtlSeconds) * time.Second)}, nil
+}
+
+func (m MockQuerier) SelectQuoteByID(ctx context.Context, id int64) (sqlc.SelectQuoteByIDRow, error) {
+	quote, found := m.Quotes[id]
+	if !found {
+		return quote, sql.ErrNoRows
+	}
+
+	return quote, nil
+}
+
+func (m MockQuerier) SelectTransactionByID(ctx context.Context, arg sqlc.SelectTransactionByIDParams) (sqlc.SelectTransactionByIDRow, error) {
+	transaction, found := m.Transactions[arg.ID]
+	if !found || (transaction.Deleted && !arg.IncludeDeleted) {
+		return sqlc.SelectTransactionByIDRow{}, sql.ErrNoRows
+	}
+
+	return transaction, nil
+}
+
+func (m MockQuerier) DeleteTransaction(ctx context.Context, id int64) (int64, error) {
+	transaction, found := m.Transactions[id]
+	if !found || transaction.Deleted {
+		return 0, sql.ErrNoRows
+	}
+
+	return id, nil
+}
+
+func (m MockQuerier) RestoreTransaction(ctx context.Context, id int64) (int64, error) {
+	transaction, found := m.Transactions[id]
+	if !found || !transaction.Deleted {
+		return 0, sql.ErrNoRows
+	}
+
+	return id, nil
+}
+
+func (m MockQuerier) UpdateTransaction(ctx context.Context, arg sqlc.UpdateTransactionParams) (int64, error) {
+	*m.Updated = arg
+	return arg.ID, nil
+}
+
+func (m MockQuerier) DeleteConversions(ctx context.Context, orderID int64) error {
+	*m.Deleted = append(*m.Deleted, orderID)
+	return nil
+}
+
+func (m MockQuerier) InsertRefund(ctx context.Context, arg sqlc.InsertRefundParams) (int64, error) {
+	*m.RefundSaved = arg
+	return 3, nil
+}
+
+func (m MockQuerier) SelectTransactionForUpdate(ctx context.Context, id int64) (sqlc.SelectTransactionForUpdateRow, error) {
+	transaction, found := m.Transactions[id]
+	if !found || transaction.Deleted {
+		return sqlc.SelectTransactionForUpdateRow{}, sql.ErrNoRows
+	}
+
+	return sqlc.SelectTransactionForUpdateRow{TransactionValue: transaction.TransactionValue, Status: transaction.Status}, nil
+}
+
+func (m MockQuerier) SelectRefunds(ctx context.Context, orderID int64) ([]sqlc.Refund, error) {
+	return m.Refunds[orderID], nil
+}
+
+func (m MockQuerier) SelectRefundsTotal(ctx context.Context, orderID int64) (decimal.Decimal, error) {
+	total := decimal.Zero
+	for _, refund := range m.Refunds[orderID] {
+		total = total.Add(refund.Amount)
+	}
+
+	return total, nil
+}
+
+func (m MockQuerier) TransitionTransactionStatus(ctx context.Context, arg sqlc.TransitionTransactionStatusParams) (sqlc.TransitionTransactionStatusRow, error) {
+	*m.Transitioned = arg
+	return sqlc.TransitionTransactionStatusRow{ID: 5, CreatedAt: time.Now()}, nil
+}
+
+func (m MockQuerier) SelectTransactionStatusHistory(ctx context.Context, orderID int64) ([]sqlc.OrderStatusHistory, error) {
+	return m.History[orderID], nil
+}
+
+func (m MockQuerier) SelectTransactionItems(ctx context.Context, orderID int64) ([]sqlc.OrderItem, error) {
+	return m.Items[orderID], nil
+}
+
+func (m MockQuerier) InsertCustomer(ctx context.Context, arg sqlc.InsertCustomerParams) (int64, error) {
+	*m.CustomerSaved = arg
+	return 4, m.InsertErr
+}
+
+func (m MockQuerier) SelectCustomerByID(ctx context.Context, id int64) (sqlc.Customer, error) {
+	customer, found := m.Customers[id]
+	if !found {
+		return customer, sql.ErrNoRows
+	}
+
+	return customer, nil
+}
+
+func (m MockQuerier) UpdateCustomer(ctx context.Context, arg sqlc.UpdateCustomerParams) (int64, error) {
+	*m.CustomerUpdated = arg
+	return arg.ID, m.InsertErr
+}
+
+func (m MockQuerier) DeleteCustomer(ctx context.Context, id int64) (int64, error) {
+	if _, found := m.Customers[id]; !found {
+		return 0, sql.ErrNoRows
+	}
+
+	for _, transaction := range m.List {
+		if transaction.CustomerID == id {
+			return 0, &pq.Error{Code: "23503"}
+		}
+	}
+
+	return id, nil
+}
+
+func (m MockQuerier) SelectTransactions(ctx context.Context, arg sqlc.SelectTransactionsParams) ([]sqlc.SelectTransactionsRow, error) {
+	if m.ListParams != nil {
+		*m.ListParams = arg
+	}
+
+	return m.List, nil
+}
+
+func (m MockQuerier) SelectTransactionsTotal(ctx context.Context, arg sqlc.SelectTransactionsTotalParams) (int64, error) {
+	return int64(len(m.List)), nil
+}
+
+// SelectCustomerTransactions pages List, sorted by id, as the query does,
+// leaving out deleted and cancelled orders.
+func (m MockQuerier) SelectCustomerTransactions(ctx context.Context, arg sqlc.SelectCustomerTransactionsParams) ([]sqlc.SelectCustomerTransactionsRow, error) {
+	if m.CustomerPages != nil {
+		*m.CustomerPages++
+	}
+
+	var rows []sqlc.SelectCustomerTransactionsRow
+	for _, transaction := range m.List {
+		if !m.isCustomerTransaction(transaction, arg.CustomerID) || transaction.ID <= arg.AfterID {
+			continue
+		}
+
+		refunded := decimal.Zero
+		for _, refund := range m.Refunds[transaction.ID] {
+			refunded = refunded.Add(refund.Amount)
+		}
+
+		rows = append(rows, sqlc.SelectCustomerTransactionsRow{
+			ID:                   transaction.ID,
+			TransactionDate:      transaction.TransactionDate,
+			TransactionValue:     transaction.TransactionValue,
+			Currency:             transaction.Currency,
+			UsdExchangeRate:      transaction.UsdExchangeRate,
+			TransactionValueUsd:  transaction.TransactionValueUsd,
+			UsdRateEffectiveDate: transaction.UsdRateEffectiveDate,
+			Refunded:             refunded,
+		})
+
+		if int64(len(rows)) == arg.PageSize {
+			break
+		}
+	}
+
+	return rows, nil
+}
+
+func (m MockQuerier) isCustomerTransaction(transaction sqlc.SelectTransactionsRow, customerID int64) bool {
+	return transaction.CustomerID == customerID && !transaction.Deleted && transaction.Status != service.StatusCancelled
+}
+
+// UpsertRateOfExchange behaves as the upsert, keyed here by country_currency_desc
+// and effective_date: unchanged rates are not written and return sql.ErrNoRows.
+func (m MockQuerier) UpsertRateOfExchange(ctx context.Context, arg sqlc.UpsertRateOfExchangeParams) (bool, error) {
+	key := arg.CountryCurrencyDesc + arg.EffectiveDate.Format("2006-01-02")
+
+	stored, exists := m.RatesOfExchange[key]
+	if exists && stored.Equal(arg.ExchangeRate) {
+		return false, sql.ErrNoRows
+	}
+
+	if m.Upserted != nil {
+		*m.Upserted = append(*m.Upserted, arg)
+	}
+
+	return !exists, nil
+}
+
+func (m MockQuerier) SelectRatesOfExchangeLastRecordDate(ctx context.Context) (string, error) {
+	return m.LastRecordDate, nil
+}
+
+func (m MockQuerier) SelectCurrencies(ctx context.Context, name string) ([]sqlc.SelectCurrenciesRow, error) {
+	if m.CurrenciesName != nil {
+		*m.CurrenciesName = name
+	}
+
+	return m.Currencies, nil
+}
+
+func (m MockQuerier) SelectRatesOfExchangeHistory(ctx context.Context, arg sqlc.SelectRatesOfExchangeHistoryParams) ([]sqlc.SelectRatesOfExchangeHistoryRow, error) {
+	if m.HistoryParams != nil {
+		*m.HistoryParams = arg
+	}
+
+	start := min(arg.PageOffset, int64(len(m.RateHistory)))
+	end := min(start+arg.PageSize, int64(len(m.RateHistory)))
+
+	return m.RateHistory[start:end], nil
+}
+
+func (m MockQuerier) SelectRatesOfExchangeHistoryTotal(ctx context.Context, arg sqlc.SelectRatesOfExchangeHistoryTotalParams) (int64, error) {
+	return int64(len(m.RateHistory)), nil
+}